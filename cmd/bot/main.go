@@ -2,17 +2,56 @@ package main
 
 import (
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"telegram-bot/internal/config"
 	"telegram-bot/internal/handlers"
+	"telegram-bot/internal/service"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// sessionSweepInterval Наибольший интервал проверки устаревших сессий
+const sessionSweepInterval = 10 * time.Minute
+
+// sessionFlushInterval Интервал записи изменений сессий в файл
+const sessionFlushInterval = 5 * time.Second
+
 func main() {
 	// Получаем токен из переменной окружения
 	token := config.GetToken()
 
+	// Загружаем опросник из файла, если он указан
+	if path := config.GetSurveyPath(); path != "" {
+		survey, err := service.LoadSurvey(path)
+		if err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+		service.UseSurvey(survey)
+	}
+
+	// Сохраняем сессии в файл, если он указан, чтобы опрос переживал перезапуск
+	if path := config.GetSessionPath(); path != "" {
+		store, err := service.NewFileSessionStore(path)
+		if err != nil {
+			log.Fatal("Ошибка загрузки сессий | ", err)
+		}
+		service.GetInstance().UseStore(store)
+		go store.Run(sessionFlushInterval, nil)
+		go flushOnShutdown(store)
+	}
+
+	// Удаляем сессии брошенных опросов
+	if ttl := config.GetSessionTTL(); ttl > 0 {
+		go service.GetInstance().SweepSessions(ttl, min(ttl, sessionSweepInterval), nil)
+	}
+
+	// По SIGHUP перечитываем опросник, не останавливая обработку обновлений
+	go reloadOnSignal(config.GetSurveyPath())
+
 	// Создаем бота
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
@@ -31,8 +70,39 @@ func main() {
 			handlers.HandleCallbackQuery(bot, update.CallbackQuery)
 		} else if update.Message != nil { // Если есть новое сообщение
 			handlers.HandleMessage(bot, update.Message)
+		} else if update.InlineQuery != nil { // Если бот вызван в другом чате (@bot HER2)
+			handlers.HandleInlineQuery(bot, update.InlineQuery)
 		} else {
 			log.Println("command not found: ", update)
 		}
 	}
 }
+
+func reloadOnSignal(path string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		problems, err := service.ReloadSurvey(path)
+		if err != nil {
+			log.Println("Ошибка обновления опросника | ", err)
+			continue
+		}
+		log.Printf("Опросник обновлен из %s, найдено ошибок: %d", path, len(problems))
+		for _, problem := range problems {
+			log.Println("  -", problem)
+		}
+	}
+}
+
+// flushOnShutdown При остановке бота записывает в файл изменения сессий, накопившиеся с последней записи
+func flushOnShutdown(store *service.FileSessionStore) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	<-signals
+	if err := store.Flush(); err != nil {
+		log.Println("Ошибка сохранения сессий | ", err)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"telegram-bot/internal/catalog"
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/service"
+)
+
+// catalog генерирует статический HTML-каталог исследований из того же опросника, что использует бот.
+// Пример: go run ./cmd/catalog -o site && open site/index.html
+func main() {
+	path := flag.String("file", config.GetSurveyPath(), "файл опросника (YAML/JSON), по умолчанию встроенный опросник")
+	output := flag.String("o", "catalog", "каталог для сохранения сайта")
+	flag.Parse()
+
+	survey := service.DefaultSurvey()
+	if *path != "" {
+		var err error
+		if survey, err = service.LoadSurvey(*path); err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+	}
+
+	if err := catalog.Generate(survey, *output, time.Now()); err != nil {
+		log.Fatal("Ошибка генерации каталога | ", err)
+	}
+	log.Printf("Каталог сохранен в %s/index.html", *output)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/importer"
+	"telegram-bot/internal/service"
+)
+
+// ctgovimport дополняет реестр исследований записями ClinicalTrials.gov API v2, скачанными заранее.
+// Пример: curl -o studies.json 'https://clinicaltrials.gov/api/v2/studies?query.term=NCT04294810'
+// && go run ./cmd/ctgovimport -json studies.json
+func main() {
+	path := flag.String("file", config.GetSurveyPath(), "файл опросника (YAML/JSON) с исследованиями и trials_file")
+	jsonPath := flag.String("json", "", "выгрузка ClinicalTrials.gov API v2 (одно исследование или ответ поиска)")
+	output := flag.String("o", "", "файл реестра исследований, по умолчанию trials_file из опросника")
+	country := flag.String("country", "Russia", "страна центров, пустая строка — все центры")
+	flag.Parse()
+
+	if *jsonPath == "" {
+		log.Fatal("Не указана выгрузка ClinicalTrials.gov (-json)")
+	}
+
+	survey := service.DefaultSurvey()
+	if *path != "" {
+		var err error
+		if survey, err = service.LoadSurvey(*path); err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+	}
+	if *output == "" {
+		*output = survey.RegistryPath(*path)
+	}
+	if *output == "" {
+		log.Fatal("Не указан файл реестра: задайте -o или trials_file в опроснике")
+	}
+
+	file, err := os.Open(*jsonPath)
+	if err != nil {
+		log.Fatal("Ошибка чтения выгрузки | ", err)
+	}
+	defer file.Close()
+
+	studies, err := importer.ReadClinicalTrials(file)
+	if err != nil {
+		log.Fatal("Ошибка разбора выгрузки | ", err)
+	}
+
+	var trials []service.Trial
+	for _, study := range studies {
+		trial := study.Trial(*country)
+		fmt.Printf("  - %s: %s, критериев включения: %d, невключения: %d, центров: %d\n",
+			trial.NCT, trial.Title, len(trial.Inclusion), len(trial.Exclusion), len(trial.Sites))
+		trials = append(trials, trial)
+	}
+
+	registry, err := service.LoadTrials(*output)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatal("Ошибка загрузки реестра | ", err)
+	}
+	if err = service.SaveTrials(*output, importer.MergeStudies(registry, survey.Trials, trials)); err != nil {
+		log.Fatal("Ошибка сохранения реестра | ", err)
+	}
+	fmt.Printf("Реестр сохранен в %s, исследований из ClinicalTrials.gov: %d\n", *output, len(trials))
+}
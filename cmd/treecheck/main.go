@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/service"
+)
+
+// treecheck проверяет дерево опросника перед выкладкой контента.
+// Завершается с ненулевым кодом, если найдены ошибки
+func main() {
+	path := flag.String("file", config.GetSurveyPath(), "файл опросника (YAML/JSON), по умолчанию встроенный опросник")
+	flag.Parse()
+
+	survey := service.DefaultSurvey()
+	source := "встроенный опросник"
+	if *path != "" {
+		var err error
+		if survey, err = service.LoadSurvey(*path); err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+		source = *path
+	}
+
+	problems := survey.Validate()
+	if len(problems) == 0 {
+		fmt.Printf("%s: ошибок не найдено\n", source)
+		return
+	}
+
+	fmt.Printf("%s: найдено ошибок: %d\n", source, len(problems))
+	for _, problem := range problems {
+		fmt.Println("  -", problem)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/graph"
+	"telegram-bot/internal/service"
+)
+
+// treegraph выгружает дерево опросника в Graphviz DOT или Mermaid для просмотра медицинским советом.
+// Пример: go run ./cmd/treegraph -format dot | dot -Tpdf -o survey.pdf
+func main() {
+	path := flag.String("file", config.GetSurveyPath(), "файл опросника (YAML/JSON), по умолчанию встроенный опросник")
+	format := flag.String("format", "dot", "формат графа: dot или mermaid")
+	output := flag.String("o", "", "файл для сохранения графа, по умолчанию стандартный вывод")
+	flag.Parse()
+
+	survey := service.DefaultSurvey()
+	if *path != "" {
+		var err error
+		if survey, err = service.LoadSurvey(*path); err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+	}
+
+	var text string
+	switch *format {
+	case "dot":
+		text = graph.Build(survey).DOT(time.Now())
+	case "mermaid":
+		text = graph.Build(survey).Mermaid(time.Now())
+	default:
+		log.Fatalf("Неизвестный формат графа %q, ожидается dot или mermaid", *format)
+	}
+
+	if *output == "" {
+		if _, err := os.Stdout.WriteString(text); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := os.WriteFile(*output, []byte(text), 0o644); err != nil {
+		log.Fatal("Ошибка записи графа | ", err)
+	}
+}
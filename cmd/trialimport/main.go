@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/importer"
+	"telegram-bot/internal/service"
+)
+
+// trialimport обновляет реестр исследований из CSV-выгрузки таблицы координаторов.
+// Пример: go run ./cmd/trialimport -csv trials.csv, затем /reload в боте.
+// Завершается с ненулевым кодом, если часть строк пропущена из-за ошибок
+func main() {
+	path := flag.String("file", config.GetSurveyPath(), "файл опросника (YAML/JSON) для проверки нозологий и поиска реестра")
+	csvPath := flag.String("csv", "", "CSV-выгрузка таблицы координаторов")
+	output := flag.String("o", "", "файл реестра исследований, по умолчанию trials_file из опросника")
+	dryRun := flag.Bool("dry-run", false, "только проверить таблицу, не сохраняя реестр")
+	flag.Parse()
+
+	if *csvPath == "" {
+		log.Fatal("Не указана CSV-выгрузка (-csv)")
+	}
+
+	survey := service.DefaultSurvey()
+	if *path != "" {
+		var err error
+		if survey, err = service.LoadSurvey(*path); err != nil {
+			log.Fatal("Ошибка загрузки опросника | ", err)
+		}
+	}
+	if *output == "" {
+		*output = survey.RegistryPath(*path)
+	}
+	if *output == "" && !*dryRun {
+		log.Fatal("Не указан файл реестра: задайте -o или trials_file в опроснике")
+	}
+
+	file, err := os.Open(*csvPath)
+	if err != nil {
+		log.Fatal("Ошибка чтения таблицы | ", err)
+	}
+	defer file.Close()
+
+	imported, problems, err := importer.ReadCSV(file, survey.Nosologies())
+	if err != nil {
+		log.Fatal("Ошибка разбора таблицы | ", err)
+	}
+
+	fmt.Printf("%s: исследований: %d, пропущено строк: %d\n", *csvPath, len(imported), len(problems))
+	for _, problem := range problems {
+		fmt.Println("  -", problem)
+	}
+
+	if !*dryRun {
+		registry, err := service.LoadTrials(*output)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Fatal("Ошибка загрузки реестра | ", err)
+		}
+		if err = service.SaveTrials(*output, importer.MergeCSV(registry, imported)); err != nil {
+			log.Fatal("Ошибка сохранения реестра | ", err)
+		}
+		fmt.Printf("Реестр сохранен в %s\n", *output)
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,228 @@
+package catalog
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/graph"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+)
+
+// maxPathsPerTrial Ограничение числа путей на странице исследования для опросников с общими вопросами
+const maxPathsPerTrial = 20
+
+// otherNosology Раздел для исследований, к которым не ведет ни один ответ опросника
+const otherNosology = "Не привязаны к опроснику"
+
+// Step Шаг пути по опроснику: вопрос и выбранный ответ
+type Step struct {
+	Question string
+	Answer   string
+}
+
+// Path Последовательность ответов, которая приводит к исследованию
+type Path []Step
+
+// Section Раздел оглавления: нозология и исследования, к которым ведут ее ответы
+type Section struct {
+	Nosology string
+	Trials   []TrialEntry
+}
+
+// TrialEntry Исследование в оглавлении и на собственной странице
+type TrialEntry struct {
+	Trial     *service.Trial
+	File      string // Имя файла страницы исследования относительно оглавления
+	Status    string
+	Recruits  bool
+	Enrolment string
+	Paths     []Path
+}
+
+// Catalog Содержимое сайта-каталога
+type Catalog struct {
+	Sections  []Section
+	Trials    []*TrialEntry
+	Generated string
+}
+
+// Build собирает каталог: исследования из реестра, пути к ним и оглавление по нозологиям.
+// Нозологией считается ответ на первый вопрос опросника, а для исследований без пути — нозология из реестра
+func Build(survey *service.Survey, now time.Time) *Catalog {
+	var (
+		catalog = &Catalog{Generated: now.Format("02.01.2006 15:04")}
+		entries = map[string]*TrialEntry{}
+		files   = map[string]bool{}
+	)
+
+	for i := range survey.Trials {
+		trial := &survey.Trials[i]
+		entry := &TrialEntry{
+			Trial:     trial,
+			File:      uniqueFileName(trial.Code, files),
+			Status:    trial.StatusText(now),
+			Recruits:  trial.IsRecruiting(now),
+			Enrolment: trial.EnrollmentPeriod(i18n.DefaultLanguage),
+		}
+		entries[trial.Code] = entry
+		catalog.Trials = append(catalog.Trials, entry)
+	}
+
+	paths := trialPaths(graph.Build(survey))
+
+	// Оглавление в порядке ответов на первый вопрос
+	var (
+		nosologies []string
+		byNosology = map[string][]string{}
+		placed     = map[string]bool{}
+	)
+	place := func(nosology, code string) {
+		if _, ok := byNosology[nosology]; !ok {
+			nosologies = append(nosologies, nosology)
+		}
+		if !slices.Contains(byNosology[nosology], code) {
+			byNosology[nosology] = append(byNosology[nosology], code)
+		}
+		placed[code] = true
+	}
+	for _, entry := range catalog.Trials {
+		code := entry.Trial.Code
+		entry.Paths = paths[code]
+		for _, path := range paths[code] {
+			place(path[0].Answer, code)
+		}
+		if len(paths[code]) == 0 && entry.Trial.Nosology != "" {
+			place(entry.Trial.Nosology, code)
+		}
+	}
+	if len(survey.Questions) > 0 {
+		nosologies = orderByOptions(nosologies, survey.Questions[0])
+	}
+
+	for _, nosology := range nosologies {
+		section := Section{Nosology: nosology}
+		for _, code := range byNosology[nosology] {
+			section.Trials = append(section.Trials, *entries[code])
+		}
+		catalog.Sections = append(catalog.Sections, section)
+	}
+
+	other := Section{Nosology: otherNosology}
+	for _, entry := range catalog.Trials {
+		if !placed[entry.Trial.Code] {
+			other.Trials = append(other.Trials, *entry)
+		}
+	}
+	if len(other.Trials) > 0 {
+		catalog.Sections = append(catalog.Sections, other)
+	}
+
+	return catalog
+}
+
+// Write сохраняет каталог в каталог dir: index.html и страницу на каждое исследование
+func (c *Catalog) Write(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, trialsDir), 0o755); err != nil {
+		return fmt.Errorf("CREATE CATALOG DIR: %w", err)
+	}
+
+	if err := writePage(filepath.Join(dir, "index.html"), indexTemplate, c); err != nil {
+		return err
+	}
+	for _, entry := range c.Trials {
+		page := struct {
+			*TrialEntry
+			Generated string
+		}{entry, c.Generated}
+		if err := writePage(filepath.Join(dir, entry.File), trialTemplate, page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generate собирает каталог и сохраняет его в dir
+func Generate(survey *service.Survey, dir string, now time.Time) error {
+	return Build(survey, now).Write(dir)
+}
+
+func writePage(path string, tmpl *template.Template, data any) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("CREATE CATALOG PAGE: %w", err)
+	}
+	defer file.Close()
+
+	if err = tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("RENDER CATALOG PAGE %s: %w", path, err)
+	}
+	return nil
+}
+
+// trialPaths Пути от первого вопроса к каждому исследованию
+func trialPaths(g *graph.Graph) map[string][]Path {
+	paths := map[string][]Path{}
+	if len(g.Questions) == 0 {
+		return paths
+	}
+
+	edges := map[*service.Question][]graph.Edge{}
+	for _, edge := range g.Edges {
+		edges[edge.From] = append(edges[edge.From], edge)
+	}
+
+	var walk func(question *service.Question, path Path)
+	walk = func(question *service.Question, path Path) {
+		for _, edge := range edges[question] {
+			next := append(append(Path{}, path...), Step{Question: question.Text, Answer: edge.Label})
+			if edge.To != nil {
+				walk(edge.To, next)
+				continue
+			}
+			if len(paths[edge.Trial]) < maxPathsPerTrial {
+				paths[edge.Trial] = append(paths[edge.Trial], next)
+			}
+		}
+	}
+	walk(g.Questions[0], nil)
+	return paths
+}
+
+// orderByOptions Упорядочивает нозологии как варианты первого вопроса
+func orderByOptions(nosologies []string, root service.Question) (ordered []string) {
+	for _, option := range root.Options {
+		if slices.Contains(nosologies, option.Text) {
+			ordered = append(ordered, option.Text)
+		}
+	}
+	for _, nosology := range nosologies {
+		if !slices.Contains(ordered, nosology) {
+			ordered = append(ordered, nosology)
+		}
+	}
+	return
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// uniqueFileName Имя файла страницы исследования из кода (коды могут содержать "/")
+func uniqueFileName(code string, used map[string]bool) string {
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(code, "_"), "_.")
+	if base == "" {
+		base = "trial"
+	}
+
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[name] = true
+	return trialsDir + "/" + name + ".html"
+}
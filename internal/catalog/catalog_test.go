@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSurvey() *service.Survey {
+	line := &service.Question{
+		ID:   "line",
+		Text: "Линия терапии",
+		Options: []service.Option{
+			{Text: "1 линия", Data: "line_first", Trials: []string{"LC/01"}},
+			{Text: "2 линия", Data: "line_second", Trials: []string{"CLOSED"}},
+		},
+	}
+
+	return &service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Меланома", Data: "q1_melanoma", NextQuestion: line},
+					{Text: "Рак легкого", Data: "q1_lung", NextQuestion: line},
+				},
+			},
+		},
+		Trials: []service.Trial{
+			{Code: "LC/01", Title: "Исследование <A&B>", Sponsor: "Спонсор", Inclusion: []string{"Возраст ≥ 18 лет"}, Exclusion: []string{"Беременность"},
+				Sites: []service.Site{{Name: "НМИЦ", City: "Москва", Investigator: "Петров П. П.", Telegram: "@coord_lung"}}},
+			{Code: "CLOSED", Title: "Закрытое", Inclusion: []string{"Критерий"}, Status: service.StatusClosed},
+			{Code: "ORPHAN", Title: "Без пути", Inclusion: []string{"Критерий"}},
+			{Code: "IMPORTED", Title: "Из таблицы", Nosology: "Рак легкого", Inclusion: []string{"Критерий"}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	catalog := Build(testSurvey(), time.Now())
+
+	var nosologies []string
+	for _, section := range catalog.Sections {
+		nosologies = append(nosologies, section.Nosology)
+	}
+	assert.Equal(t, []string{"Меланома", "Рак легкого", otherNosology}, nosologies, "Разделы в порядке вариантов первого вопроса")
+	assert.Len(t, catalog.Sections[0].Trials, 2)
+	assert.Equal(t, "IMPORTED", catalog.Sections[1].Trials[2].Trial.Code, "Исследование без пути попадает в нозологию из реестра")
+	assert.Len(t, catalog.Sections[2].Trials, 1)
+	assert.Equal(t, "ORPHAN", catalog.Sections[2].Trials[0].Trial.Code)
+
+	entry := catalog.Trials[0]
+	assert.Equal(t, "trials/LC_01.html", entry.File, "Косая черта в коде не создает подкаталог")
+	assert.Equal(t, []Path{
+		{{Question: "Выберите нозологию", Answer: "Меланома"}, {Question: "Линия терапии", Answer: "1 линия"}},
+		{{Question: "Выберите нозологию", Answer: "Рак легкого"}, {Question: "Линия терапии", Answer: "1 линия"}},
+	}, entry.Paths, "Общий вопрос дает путь через каждую нозологию")
+	assert.Empty(t, catalog.Trials[2].Paths)
+}
+
+func TestUniqueFileName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "trials/A_1.html", uniqueFileName("A/1", used))
+	assert.Equal(t, "trials/A_1_2.html", uniqueFileName("A 1", used))
+	assert.Equal(t, "trials/trial.html", uniqueFileName("Исследование", used))
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	if !assert.NoError(t, Generate(testSurvey(), dir, time.Now())) {
+		return
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), `href="trials/LC_01.html"`)
+	assert.Contains(t, string(index), "Исследование &lt;A&amp;B&gt;", "Текст экранируется")
+	assert.Contains(t, string(index), "набор завершен")
+
+	page, err := os.ReadFile(filepath.Join(dir, "trials", "LC_01.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), "<li>Возраст ≥ 18 лет</li>")
+	assert.Contains(t, string(page), "<li>Беременность</li>")
+	assert.Contains(t, string(page), `Выберите нозологию <span class="answer">Рак легкого</span> → Линия терапии <span class="answer">1 линия</span>`)
+	assert.Contains(t, string(page), `href="../index.html"`)
+	assert.Contains(t, string(page), `Главный исследователь: Петров П. П.<br>Координатор: <a href="https://t.me/coord_lung">@coord_lung</a>`)
+
+	orphan, err := os.ReadFile(filepath.Join(dir, "trials", "ORPHAN.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(orphan), "Ни один ответ опросника не ведет к этому исследованию")
+}
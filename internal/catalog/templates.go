@@ -0,0 +1,99 @@
+package catalog
+
+import "html/template"
+
+// trialsDir Подкаталог со страницами исследований
+const trialsDir = "trials"
+
+// style Стили встраиваются в каждую страницу, чтобы сайт не зависел от внешних файлов
+const style = `<style>
+body { font-family: Helvetica, Arial, sans-serif; max-width: 860px; margin: 2em auto; padding: 0 1em; color: #222; line-height: 1.45; }
+h1 { font-size: 1.6em; } h2 { font-size: 1.25em; margin-top: 1.6em; border-bottom: 1px solid #ddd; padding-bottom: .2em; }
+a { color: #0b5cad; } ul { padding-left: 1.3em; } li { margin: .25em 0; }
+.code { font-weight: bold; white-space: nowrap; }
+.status { display: inline-block; padding: 0 .5em; border-radius: 4px; font-size: .85em; background: #c8f7c5; }
+.status.closed { background: #eee; color: #888; }
+.details td { padding: .15em 1em .15em 0; vertical-align: top; } .details td:first-child { color: #666; }
+.path { color: #444; } .path .answer { font-weight: bold; }
+footer { margin-top: 3em; color: #888; font-size: .85em; }
+</style>`
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Каталог клинических исследований</title>
+` + style + `
+</head>
+<body>
+<h1>Каталог клинических исследований</h1>
+{{range .Sections}}
+<h2>{{.Nosology}}</h2>
+<ul>
+{{- range .Trials}}
+  <li><a class="code" href="{{.File}}">{{.Trial.Code}}</a> — {{.Trial.Title}} <span class="status{{if not .Recruits}} closed{{end}}">{{.Status}}</span></li>
+{{- end}}
+</ul>
+{{else}}
+<p>В реестре нет исследований.</p>
+{{end}}
+<footer>Сформировано {{.Generated}} из содержимого опросника бота.</footer>
+</body>
+</html>
+`))
+
+var trialTemplate = template.Must(template.New("trial").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Trial.Code}} — каталог клинических исследований</title>
+` + style + `
+</head>
+<body>
+<p><a href="../index.html">← Все исследования</a></p>
+<h1>{{.Trial.Code}}</h1>
+<p>{{.Trial.Title}}</p>
+<table class="details">
+{{- with .Trial.Sponsor}}<tr><td>Спонсор</td><td>{{.}}</td></tr>{{end}}
+{{- with .Trial.Phase}}<tr><td>Фаза</td><td>{{.}}</td></tr>{{end}}
+{{- with .Trial.NCT}}<tr><td>ClinicalTrials.gov</td><td><a href="https://clinicaltrials.gov/study/{{.}}">{{.}}</a></td></tr>{{end}}
+{{- with .Trial.Conditions}}<tr><td>Заболевания</td><td>{{range $i, $c := .}}{{if $i}}, {{end}}{{$c}}{{end}}</td></tr>{{end}}
+<tr><td>Статус</td><td><span class="status{{if not .Recruits}} closed{{end}}">{{.Status}}</span></td></tr>
+{{- with .Enrolment}}<tr><td>Набор</td><td>{{.}}</td></tr>{{end}}
+{{- with .Trial.Contacts}}<tr><td>Контакты</td><td>{{.}}</td></tr>{{end}}
+</table>
+{{with .Trial.Inclusion}}
+<h2>Критерии включения</h2>
+<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+{{end}}
+{{with .Trial.Exclusion}}
+<h2>Критерии невключения</h2>
+<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+{{end}}
+{{with .Trial.Sites}}
+<h2>Исследовательские центры</h2>
+<ul>
+{{- range .}}
+  <li>{{.Name}}{{with .City}}, {{.}}{{end}}
+    {{- with .Address}}<br>{{.}}{{end}}
+    {{- with .Investigator}}<br>Главный исследователь: {{.}}{{end}}
+    {{- if or .Phone .ChatURL}}<br>Координатор:{{with .Phone}} {{.}}{{end}}{{if .ChatURL}} <a href="{{.ChatURL}}">@{{.TelegramUsername}}</a>{{end}}{{end}}</li>
+{{- end}}
+</ul>
+{{end}}
+<h2>Как найти в боте</h2>
+{{with .Paths}}
+<ol>
+{{- range .}}
+  <li class="path">{{range $i, $step := .}}{{if $i}} → {{end}}{{$step.Question}} <span class="answer">{{$step.Answer}}</span>{{end}}</li>
+{{- end}}
+</ol>
+{{else}}
+<p>Ни один ответ опросника не ведет к этому исследованию.</p>
+{{end}}
+<footer>Сформировано {{.Generated}} из содержимого опросника бота.</footer>
+</body>
+</html>
+`))
@@ -1,22 +1,84 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// loadEnvOnce Файл .env читается один раз при первом обращении к настройкам
+var loadEnvOnce sync.Once
+
+// getenv возвращает переменную окружения, предварительно загрузив файл .env.
+// Файл не обязателен: переменные окружения процесса имеют приоритет над ним
+func getenv(key string) string {
+	loadEnvOnce.Do(func() {
+		if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Fatal("Ошибка загрузки .env файла | ", err)
+		}
+	})
+	return os.Getenv(key)
+}
+
 // GetToken возвращает токен бота из переменной окружения
 func GetToken() string {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Ошибка загрузки .env файла | ", err)
-	}
-
-	token := os.Getenv("TELEGRAM_BOT_TOKEN")
+	token := getenv("TELEGRAM_BOT_TOKEN")
 	if token == "" {
 		log.Fatal("TELEGRAM_BOT_TOKEN не установлен")
 	}
 	return token
 }
+
+// GetSurveyPath возвращает путь к файлу опросника (YAML/JSON).
+// Пустая строка означает использование встроенного опросника
+func GetSurveyPath() string {
+	return getenv("SURVEY_FILE")
+}
+
+// GetDocumentCachePath возвращает путь к файлу кэша file_id отправленных документов.
+// Пустая строка означает кэш только в памяти: после перезапуска документы загружаются заново
+func GetDocumentCachePath() string {
+	return getenv("DOCUMENT_CACHE_FILE")
+}
+
+// GetSessionPath возвращает путь к файлу хранилища сессий опроса.
+// Пустая строка означает хранение сессий в памяти: после перезапуска опрос начинается заново
+func GetSessionPath() string {
+	return getenv("SESSION_FILE")
+}
+
+// defaultSessionTTL Время бездействия, после которого сессия опроса удаляется, если SESSION_TTL не задан
+const defaultSessionTTL = 24 * time.Hour
+
+// GetSessionTTL возвращает время бездействия, после которого сессия опроса удаляется
+// (SESSION_TTL в формате Go, например "12h" или "90m"). "0" отключает удаление
+func GetSessionTTL() time.Duration {
+	value := getenv("SESSION_TTL")
+	if value == "" {
+		return defaultSessionTTL
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		log.Println("Некорректный SESSION_TTL, используется значение по умолчанию | ", value)
+		return defaultSessionTTL
+	}
+	return ttl
+}
+
+// IsAdmin проверяет, входит ли пользователь в список администраторов (ADMIN_IDS через запятую)
+func IsAdmin(userID int64) bool {
+	for _, field := range strings.Split(getenv("ADMIN_IDS"), ",") {
+		adminID, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err == nil && adminID == userID {
+			return true
+		}
+	}
+	return false
+}
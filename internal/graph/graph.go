@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/service"
+)
+
+// Graph Граф опросника: вопросы и исследования как узлы, варианты ответа как подписанные ребра
+type Graph struct {
+	Questions []*service.Question
+	Trials    []string // Коды исследований в порядке первого упоминания
+	Edges     []Edge
+
+	survey *service.Survey
+}
+
+// Edge Переход от вопроса к следующему вопросу или к исследованию
+type Edge struct {
+	From  *service.Question
+	To    *service.Question // nil для перехода к исследованию
+	Trial string
+	Label string
+}
+
+// Build строит граф, начиная с вопросов верхнего уровня.
+// Общий вопрос, на который ведет несколько вариантов, становится одним узлом
+func Build(survey *service.Survey) *Graph {
+	var (
+		graph     = &Graph{survey: survey}
+		visited   = map[*service.Question]bool{}
+		seenTrial = map[string]bool{}
+		walk      func(question *service.Question)
+	)
+
+	walk = func(question *service.Question) {
+		if visited[question] {
+			return
+		}
+		visited[question] = true
+		graph.Questions = append(graph.Questions, question)
+
+		for _, target := range targets(question) {
+			for _, code := range target.option.Trials {
+				graph.Edges = append(graph.Edges, Edge{From: question, Trial: code, Label: target.label})
+				if !seenTrial[code] {
+					seenTrial[code] = true
+					graph.Trials = append(graph.Trials, code)
+				}
+			}
+
+			if next := target.option.NextQuestion; next != nil {
+				graph.Edges = append(graph.Edges, Edge{From: question, To: next, Label: target.label})
+				walk(next)
+			}
+		}
+	}
+
+	for i := range survey.Questions {
+		walk(&survey.Questions[i])
+	}
+	return graph
+}
+
+// target Вариант перехода с подписью для ребра
+type target struct {
+	option *service.Option
+	label  string
+}
+
+func targets(question *service.Question) (result []target) {
+	if !question.IsMulti() {
+		for i := range question.Options {
+			result = append(result, target{option: &question.Options[i], label: question.Options[i].Text})
+		}
+	}
+	for i := range question.Routes {
+		result = append(result, target{option: &question.Routes[i].Option, label: routeLabel(question, &question.Routes[i])})
+	}
+	for i := range question.Rules {
+		rule := &question.Rules[i]
+		label := "иначе"
+		if rule.When != "" {
+			label = "если " + rule.When
+		}
+		result = append(result, target{option: &rule.Option, label: label})
+	}
+	return
+}
+
+// routeLabel Подпись правила перехода: диапазон числа или условия на выбранные варианты
+func routeLabel(question *service.Question, route *service.Route) string {
+	var parts []string
+
+	switch {
+	case route.Min != nil && route.Max != nil:
+		parts = append(parts, formatNumber(*route.Min)+"–"+formatNumber(*route.Max))
+	case route.Min != nil:
+		parts = append(parts, "≥ "+formatNumber(*route.Min))
+	case route.Max != nil:
+		parts = append(parts, "≤ "+formatNumber(*route.Max))
+	}
+
+	optionTexts := func(data []string) string {
+		var texts []string
+		for _, value := range data {
+			text := value
+			for _, option := range question.Options {
+				if option.Matches(value) {
+					text = option.Text
+				}
+			}
+			texts = append(texts, text)
+		}
+		return strings.Join(texts, ", ")
+	}
+	if len(route.All) > 0 {
+		parts = append(parts, "все: "+optionTexts(route.All))
+	}
+	if len(route.Any) > 0 {
+		parts = append(parts, "любой: "+optionTexts(route.Any))
+	}
+	if len(route.None) > 0 {
+		parts = append(parts, "ни одного: "+optionTexts(route.None))
+	}
+
+	if len(parts) == 0 {
+		return "иначе"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// trialState Состояние исследования для оформления узла
+type trialState int
+
+const (
+	trialRecruiting trialState = iota
+	trialNotRecruiting
+	trialClosed
+	trialMissing
+)
+
+func (g *Graph) trialState(code string, now time.Time) trialState {
+	trial := g.survey.GetTrial(code)
+	switch {
+	case trial == nil:
+		return trialMissing
+	case trial.IsClosed(now):
+		return trialClosed
+	case !trial.IsRecruiting(now):
+		return trialNotRecruiting
+	default:
+		return trialRecruiting
+	}
+}
+
+func (g *Graph) trialLabel(code string, now time.Time) string {
+	trial := g.survey.GetTrial(code)
+	if trial == nil {
+		return code + "\nнет в реестре"
+	}
+	return code + "\n" + trial.StatusText(now)
+}
+
+func questionLabel(question *service.Question) string {
+	return question.ID + "\n" + question.Text
+}
+
+func formatNumber(value float64) string {
+	return strings.Replace(fmt.Sprint(value), ".", ",", 1)
+}
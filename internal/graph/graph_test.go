@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSurvey() *service.Survey {
+	fifty := 50.0
+	line := &service.Question{
+		ID:   "line",
+		Text: "Линия терапии",
+		Options: []service.Option{
+			{Text: "1 линия", Data: "line_first", Trials: []string{"OPEN"}},
+			{Text: "2 линия", Data: "line_second", Trials: []string{"CLOSED", "MISSING"}},
+		},
+	}
+
+	return &service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак \"легкого\"", Data: "q1_lung", NextQuestion: &service.Question{
+						ID:     "pdl1",
+						Text:   "PD-L1",
+						Type:   service.QuestionNumber,
+						Routes: []service.Route{{Min: &fifty, Option: service.Option{Data: "pdl1_high", NextQuestion: line}}},
+						Rules:  []service.Rule{{When: "q1 = q1_lung", Option: service.Option{Data: "pdl1_rule", Trials: []string{"OPEN"}}}},
+					}},
+					{Text: "Меланома", Data: "q1_melanoma", NextQuestion: &service.Question{
+						ID:   "markers",
+						Text: "Мутации",
+						Type: service.QuestionMulti,
+						Options: []service.Option{
+							{Text: "BRAF", Data: "markers_braf"},
+						},
+						Routes: []service.Route{{All: []string{"markers_braf"}, Option: service.Option{Data: "markers_route", NextQuestion: line}}},
+					}},
+				},
+			},
+		},
+		Trials: []service.Trial{
+			{Code: "OPEN", Title: "Открытое", Inclusion: []string{"Критерий"}},
+			{Code: "CLOSED", Title: "Закрытое", Inclusion: []string{"Критерий"}, Status: service.StatusClosed},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	graph := Build(testSurvey())
+
+	var ids []string
+	for _, question := range graph.Questions {
+		ids = append(ids, question.ID)
+	}
+	assert.Equal(t, []string{"q1", "pdl1", "line", "markers"}, ids, "Общий вопрос - один узел")
+	assert.Equal(t, []string{"OPEN", "CLOSED", "MISSING"}, graph.Trials)
+
+	var labels []string
+	for _, edge := range graph.Edges {
+		labels = append(labels, edge.Label)
+	}
+	assert.Contains(t, labels, "≥ 50")
+	assert.Contains(t, labels, "если q1 = q1_lung")
+	assert.Contains(t, labels, "все: BRAF")
+}
+
+func TestDOT(t *testing.T) {
+	dot := Build(testSurvey()).DOT(time.Now())
+
+	assert.True(t, strings.HasPrefix(dot, "digraph survey {"))
+	assert.Contains(t, dot, `"question:q1" -> "question:pdl1" [label="Рак \"легкого\""];`)
+	assert.Contains(t, dot, `"question:line" -> "trial:CLOSED" [label="2 линия"];`)
+	assert.Contains(t, dot, `"trial:OPEN" [label="OPEN\nнабор открыт", shape=box, style="rounded,filled", fillcolor="#c8f7c5"];`)
+	assert.Contains(t, dot, `"trial:CLOSED" [label="CLOSED\nнабор завершен", shape=box, style="rounded,filled,dashed", fillcolor="#eeeeee"`)
+	assert.Contains(t, dot, `"trial:MISSING" [label="MISSING\nнет в реестре"`)
+}
+
+func TestMermaid(t *testing.T) {
+	mermaid := Build(testSurvey()).Mermaid(time.Now())
+
+	assert.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+	assert.Contains(t, mermaid, `q0["q1<br/>Выберите нозологию"]`)
+	assert.Contains(t, mermaid, `q0 -->|"Рак #quot;легкого#quot;"| q1`)
+	assert.Contains(t, mermaid, `q2 -->|"2 линия"| t1`)
+	assert.Contains(t, mermaid, "class t0 recruiting")
+	assert.Contains(t, mermaid, "class t1 closed")
+	assert.Contains(t, mermaid, "class t2 missing")
+}
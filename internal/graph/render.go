@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/service"
+)
+
+// Оформление узлов исследований в DOT по состоянию набора
+var dotTrialStyles = map[trialState]string{
+	trialRecruiting:    `shape=box, style="rounded,filled", fillcolor="#c8f7c5"`,
+	trialNotRecruiting: `shape=box, style="rounded,filled", fillcolor="#fff3b0"`,
+	trialClosed:        `shape=box, style="rounded,filled,dashed", fillcolor="#eeeeee", fontcolor="#999999", color="#999999"`,
+	trialMissing:       `shape=box, style="rounded,dashed", color="#d00000", fontcolor="#d00000"`,
+}
+
+// Классы узлов исследований в Mermaid по состоянию набора
+var mermaidTrialClasses = map[trialState]string{
+	trialRecruiting:    "recruiting",
+	trialNotRecruiting: "notRecruiting",
+	trialClosed:        "closed",
+	trialMissing:       "missing",
+}
+
+// DOT возвращает граф в формате Graphviz
+func (g *Graph) DOT(now time.Time) string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph survey {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString(`  node [shape=box, fontname="Helvetica"];` + "\n")
+	builder.WriteString(`  edge [fontname="Helvetica", fontsize=10];` + "\n\n")
+
+	for _, question := range g.Questions {
+		fmt.Fprintf(&builder, "  %s [label=%s];\n", dotQuestionID(question), dotQuote(questionLabel(question)))
+	}
+	for _, code := range g.Trials {
+		fmt.Fprintf(&builder, "  %s [label=%s, %s];\n",
+			dotQuote("trial:"+code), dotQuote(g.trialLabel(code, now)), dotTrialStyles[g.trialState(code, now)])
+	}
+	builder.WriteString("\n")
+
+	for _, edge := range g.Edges {
+		to := dotQuote("trial:" + edge.Trial)
+		if edge.To != nil {
+			to = dotQuestionID(edge.To)
+		}
+		fmt.Fprintf(&builder, "  %s -> %s [label=%s];\n", dotQuestionID(edge.From), to, dotQuote(edge.Label))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// Mermaid возвращает граф в формате Mermaid flowchart
+func (g *Graph) Mermaid(now time.Time) string {
+	var (
+		builder   strings.Builder
+		questions = map[*service.Question]string{}
+		trials    = map[string]string{}
+	)
+
+	for i, question := range g.Questions {
+		questions[question] = "q" + strconv.Itoa(i)
+	}
+	for i, code := range g.Trials {
+		trials[code] = "t" + strconv.Itoa(i)
+	}
+
+	builder.WriteString("flowchart LR\n")
+	for _, question := range g.Questions {
+		fmt.Fprintf(&builder, "  %s[\"%s\"]\n", questions[question], mermaidEscape(questionLabel(question)))
+	}
+	for _, code := range g.Trials {
+		fmt.Fprintf(&builder, "  %s([\"%s\"])\n", trials[code], mermaidEscape(g.trialLabel(code, now)))
+	}
+
+	for _, edge := range g.Edges {
+		to := trials[edge.Trial]
+		if edge.To != nil {
+			to = questions[edge.To]
+		}
+		fmt.Fprintf(&builder, "  %s -->|\"%s\"| %s\n", questions[edge.From], mermaidEscape(edge.Label), to)
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString("  classDef recruiting fill:#c8f7c5,stroke:#2e7d32\n")
+	builder.WriteString("  classDef notRecruiting fill:#fff3b0,stroke:#b28704\n")
+	builder.WriteString("  classDef closed fill:#eeeeee,stroke:#999999,color:#999999,stroke-dasharray:5 5\n")
+	builder.WriteString("  classDef missing fill:#ffffff,stroke:#d00000,color:#d00000,stroke-dasharray:5 5\n")
+	for _, code := range g.Trials {
+		fmt.Fprintf(&builder, "  class %s %s\n", trials[code], mermaidTrialClasses[g.trialState(code, now)])
+	}
+	return builder.String()
+}
+
+func dotQuestionID(question *service.Question) string {
+	return dotQuote("question:" + question.ID)
+}
+
+// dotQuote Строка DOT в кавычках с экранированием
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+// mermaidEscape Экранирование текста подписи Mermaid
+func mermaidEscape(text string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "\n", "<br/>", "|", "#124;")
+	return replacer.Replace(text)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"telegram-bot/internal/helper"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// checklistAnswers Ответы на критерии по callback data кнопок
+var checklistAnswers = map[string]service.CriterionAnswer{
+	service.CallbackCheckYes:     service.AnswerYes,
+	service.CallbackCheckNo:      service.AnswerNo,
+	service.CallbackCheckUnknown: service.AnswerUnknown,
+}
+
+// Обработка кнопок проверки критериев. Возвращает false, если callback к проверке не относится
+func handleChecklistCallback(bot BotInterface, conv conversation, messageID int, data string) bool {
+	surveyService := service.GetInstance()
+	key := conv.session(messageID)
+
+	if code, ok := strings.CutPrefix(data, service.CallbackCheckPrefix); ok {
+		trial := service.CurrentSurvey().GetTrial(code)
+		if trial == nil {
+			log.Println("trial not found in registry:", code)
+			return true
+		}
+
+		surveyService.StartChecklist(key, trial)
+		editChecklist(bot, conv, messageID)
+		return true
+	}
+
+	if answer, ok := checklistAnswers[data]; ok {
+		if err := surveyService.SaveChecklistAnswer(key, answer); err != nil {
+			log.Println(err)
+			editSessionExpired(bot, conv, messageID)
+			return true
+		}
+		editChecklist(bot, conv, messageID)
+		return true
+	}
+
+	if data == service.CallbackCheckBack {
+		if err := surveyService.PopChecklistAnswer(key); err != nil {
+			// Отвечать больше не на что - возвращаемся к карточке исследования
+			if trial, _ := surveyService.GetChecklist(key); trial != nil {
+				sendTrialCard(bot, messageID, conv, trial, trialCard{cardData: service.CallbackTrialPrefix + trial.Code})
+			} else {
+				log.Println(err)
+				editSessionExpired(bot, conv, messageID)
+			}
+			return true
+		}
+		editChecklist(bot, conv, messageID)
+		return true
+	}
+
+	return false
+}
+
+// Показ очередного критерия или итога проверки
+func editChecklist(bot BotInterface, conv conversation, messageID int) {
+	trial, answers := service.GetInstance().GetChecklist(conv.session(messageID))
+	if trial == nil {
+		log.Println("checklist not found:", conv.ChatID, conv.UserID)
+		return
+	}
+
+	lang := conv.lang()
+	trial = trial.In(lang)
+
+	criteria := trial.Criteria()
+	if len(answers) >= len(criteria) {
+		sendVerdict(bot, conv, messageID, trial, service.EvaluateChecklist(criteria, answers))
+		return
+	}
+
+	var (
+		builder   strings.Builder
+		criterion = criteria[len(answers)]
+		title     = i18n.T(lang, "Критерий включения %d из %d", len(answers)+1, len(trial.Inclusion))
+		prompt    = i18n.T(lang, "Пациент соответствует критерию?")
+	)
+	if criterion.Exclusion {
+		title = i18n.T(lang, "Критерий невключения %d из %d", len(answers)-len(trial.Inclusion)+1, len(trial.Exclusion))
+		prompt = i18n.T(lang, "Есть ли это у пациента?")
+	}
+
+	builder.WriteString("🔎 *" + helper.EscapeMarkdownV2(i18n.T(lang, "Проверка критериев")) + ":* " + helper.EscapeMarkdownV2(trial.Code) + "\n\n")
+	builder.WriteString("*" + helper.EscapeMarkdownV2(title) + "*\n")
+	builder.WriteString(helper.EscapeMarkdownV2(criterion.Text) + "\n\n")
+	builder.WriteString("_" + helper.EscapeMarkdownV2(prompt) + "_")
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "✅ Да"), service.CallbackCheckYes),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "❌ Нет"), service.CallbackCheckNo),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "❔ Неизвестно"), service.CallbackCheckUnknown),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "Назад"), service.CallbackCheckBack),
+		),
+	)
+
+	editResultMessage(bot, messageID, conv, builder.String(), keyboard)
+}
+
+// Отправка итога проверки критериев
+func sendVerdict(bot BotInterface, conv conversation, messageID int, trial *service.Trial, verdict service.Verdict) {
+	var builder strings.Builder
+
+	lang := conv.lang()
+	code := helper.EscapeMarkdownV2(trial.Code)
+	bold := func(text string) string {
+		return "*" + helper.EscapeMarkdownV2(i18n.T(lang, text)) + "* "
+	}
+
+	switch verdict.Kind {
+	case service.VerdictEligible:
+		builder.WriteString("✅ " + bold("Пациент вероятно подходит для исследования") + code + "\n\n")
+		builder.WriteString(helper.EscapeMarkdownV2(i18n.T(lang, "Критерии включения выполнены, критерии невключения отсутствуют.")))
+	case service.VerdictNotEligible:
+		builder.WriteString("❌ " + bold("Пациент не подходит для исследования") + code + "\n")
+		writeBulletList(&builder, i18n.T(lang, "Не выполнены критерии"), criteriaTexts(verdict.Failed, lang))
+	case service.VerdictNeedsData:
+		builder.WriteString("❔ " + bold("Нужны дополнительные данные для исследования") + code + "\n")
+		writeBulletList(&builder, i18n.T(lang, "Нет данных по критериям"), criteriaTexts(verdict.Unknown, lang))
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "◀ Изменить ответ"), service.CallbackCheckBack),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📋 Описание"), service.CallbackTrialPrefix+trial.Code),
+		),
+		restartKeyboardRow(lang),
+	)
+
+	editResultMessage(bot, messageID, conv, builder.String(), keyboard)
+}
+
+// criteriaTexts Сокращенные тексты критериев с пометкой о типе
+func criteriaTexts(criteria []service.Criterion, lang string) (texts []string) {
+	for _, criterion := range criteria {
+		format := "(включения) %s"
+		if criterion.Exclusion {
+			format = "(невключения) %s"
+		}
+		texts = append(texts, i18n.T(lang, format, shortText(criterion.Text)))
+	}
+	return
+}
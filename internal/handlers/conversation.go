@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// conversation Участник чата, для которого бот обрабатывает обновление.
+// В группе у каждого участника свои сессии опроса и язык, а новые сообщения бота отправляются
+// ответом на сообщение replyTo: ответ попадает в ту же тему форума, что и исходное сообщение.
+//
+// Отправка в тему по message_thread_id отложена до обновления библиотеки: версия 5.5.1 не читает
+// message_thread_id из обновлений и не позволяет передать его при отправке (Chattable закрыт для
+// реализации вне пакета). Пока тема определяется ответом, поэтому если исходное сообщение удалено,
+// сообщение бота попадает в общую тему (General) форума
+type conversation struct {
+	service.ChatUser
+	group   bool
+	replyTo int
+}
+
+// newConversation Участник чата по сообщению и его отправителю.
+// Без отправителя (пост в канале) участником считается сам чат
+func newConversation(chat *tgbotapi.Chat, from *tgbotapi.User, replyTo int) conversation {
+	user := service.ChatUser{ChatID: chat.ID, UserID: chat.ID}
+	if from != nil {
+		user.UserID = from.ID
+	}
+	return conversation{ChatUser: user, group: chat.IsGroup() || chat.IsSuperGroup(), replyTo: replyTo}
+}
+
+// lang Язык интерфейса участника
+func (c conversation) lang() string {
+	return language(c.UserID)
+}
+
+// session Ключ сессии опроса участника в сообщении messageID
+func (c conversation) session(messageID int) service.SessionKey {
+	return service.SessionKey{ChatUser: c.ChatUser, MessageID: messageID}
+}
+
+// newMessage Новое сообщение в чат участника
+func (c conversation) newMessage(text string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(c.ChatID, text)
+	c.reply(&msg.BaseChat)
+	return msg
+}
+
+// reply В группе делает новое сообщение ответом на replyTo. Удаленное сообщение replyTo
+// не мешает отправке, но тогда ответ попадает в общую тему форума
+func (c conversation) reply(chat *tgbotapi.BaseChat) {
+	if c.group && c.replyTo != 0 {
+		chat.ReplyToMessageID = c.replyTo
+		chat.AllowSendingWithoutReply = true
+	}
+}
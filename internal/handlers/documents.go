@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"log"
+	"sync"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var (
+	documentCache     *service.DocumentCache
+	documentCacheOnce sync.Once
+)
+
+// documents возвращает кэш file_id документов. Создается при первой отправке, когда окружение уже загружено
+func documents() *service.DocumentCache {
+	documentCacheOnce.Do(func() {
+		var err error
+		if documentCache, err = service.NewDocumentCache(config.GetDocumentCachePath()); err != nil {
+			log.Println("Error loading document cache:", err)
+		}
+	})
+	return documentCache
+}
+
+// Отправка документов исследования. Загруженный однажды файл повторно отправляется по file_id
+func sendDocuments(bot BotInterface, conv conversation, code string) {
+	lang := conv.lang()
+
+	trial := service.CurrentSurvey().GetTrial(code)
+	if trial == nil || len(trial.Documents) == 0 {
+		log.Println("trial documents not found:", code)
+		return
+	}
+
+	for _, document := range trial.Documents {
+		title := document.Title
+		if title == "" {
+			title = i18n.T(lang, "Синопсис протокола")
+		}
+
+		var file tgbotapi.RequestFileData = tgbotapi.FilePath(document.Path())
+		fileID := documents().Get(document.Path())
+		if fileID != "" {
+			file = tgbotapi.FileID(fileID)
+		}
+
+		msg := tgbotapi.NewDocument(conv.ChatID, file)
+		conv.reply(&msg.BaseChat)
+		msg.Caption = "📄 " + title + " — " + trial.Code
+
+		sentMsg, err := bot.Send(msg)
+		if err != nil {
+			log.Println("Error sending document:", document.Path(), err)
+			continue
+		}
+		if fileID == "" && sentMsg.Document != nil {
+			if err = documents().Set(document.Path(), sentMsg.Document.FileID); err != nil {
+				log.Println("Error caching document:", err)
+			}
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package handlers
 
 import (
-	"errors"
-	"fmt"
 	"log"
-	"telegram-bot/internal/helper"
+	"strings"
+	"telegram-bot/internal/config"
+	"time"
 
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/service"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -21,45 +22,100 @@ func HandleCallbackQuery(bot BotInterface, callbackQuery *tgbotapi.CallbackQuery
 	var (
 		currentQuestion *service.Question
 		option          service.Option
-		chatID          int64
 	)
 
-	chatID = callbackQuery.Message.Chat.ID
+	// Кнопки сообщений, отправленных через inline-режим, не связаны с чатом опроса
+	if callbackQuery.Message == nil {
+		answerCallback(bot, callbackQuery.ID, "")
+		return
+	}
+
+	messageID := callbackQuery.Message.MessageID
+	conv := newConversation(callbackQuery.Message.Chat, callbackQuery.From, messageID)
+	key := conv.session(messageID)
 	surveyService := service.GetInstance()
+	detectLanguage(callbackQuery.From)
+
+	// В группе кнопками сообщения опроса управляет только участник, для которого оно отправлено
+	if owner := surveyService.GetMessageOwner(conv.ChatID, messageID); owner != 0 && owner != conv.UserID {
+		answerCallback(bot, callbackQuery.ID, i18n.T(conv.lang(), "Это опрос другого участника. Отправьте /start, чтобы начать свой"))
+		return
+	}
 
-	if callbackQuery.Data == "start" {
-		surveyService.Start(chatID)
-		editQuestion(
-			bot,
-			chatID,
-			surveyService.GetLastMessageID(chatID),
-			surveyService.GetCurrentQuestion(chatID),
-		)
+	if callbackQuery.Data == service.CallbackStart {
+		// Опрос начинается заново в нажатом сообщении
+		surveyService.Start(key)
+		surveyService.SetLastMessageID(conv.ChatUser, messageID)
+		editQuestion(bot, conv, key, surveyService.GetCurrentQuestion(key))
 		return
 	}
 
-	if callbackQuery.Data == "back" {
-		if len(surveyService.GetQuestionsStack(chatID)) > 0 {
-			prevQuestion, err := surveyService.PopFromQuestionStack(chatID)
+	if callbackQuery.Data == service.CallbackBack {
+		if surveyService.GetCurrentQuestion(key) == nil {
+			sessionExpired(bot, conv, callbackQuery)
+			return
+		}
+		if len(surveyService.GetQuestionsStack(key)) > 0 {
+			prevQuestion, err := surveyService.PopFromQuestionStack(key)
 			if err != nil {
 				log.Println(err)
 				return
 			}
 
-			if err = surveyService.SetCurrentQuestion(chatID, prevQuestion); err != nil {
+			if err = surveyService.SetCurrentQuestion(key, prevQuestion); err != nil {
 				log.Println(err)
 				return
 			}
 
-			editQuestion(bot, chatID, surveyService.GetLastMessageID(chatID), prevQuestion)
+			editQuestion(bot, conv, key, prevQuestion)
 		}
 		return
 	}
 
-	currentQuestion = surveyService.GetCurrentQuestion(chatID)
+	if payload, ok := strings.CutPrefix(callbackQuery.Data, service.CallbackResultsPrefix); ok {
+		showResults(bot, conv, messageID, payload, 0)
+		return
+	}
+
+	if code, ok := strings.CutPrefix(callbackQuery.Data, service.CallbackTrialPrefix); ok {
+		showTrial(bot, conv, messageID, code, 0)
+		return
+	}
+
+	if payload, ok := strings.CutPrefix(callbackQuery.Data, service.CallbackPagePrefix); ok {
+		showTrialPage(bot, conv, messageID, payload)
+		answerCallback(bot, callbackQuery.ID, "")
+		return
+	}
+
+	if code, ok := strings.CutPrefix(callbackQuery.Data, service.CallbackDocPrefix); ok {
+		sendDocuments(bot, conv, code)
+		answerCallback(bot, callbackQuery.ID, "")
+		return
+	}
+
+	if code, ok := strings.CutPrefix(callbackQuery.Data, service.CallbackNearPrefix); ok {
+		requestLocation(bot, conv, code)
+		answerCallback(bot, callbackQuery.ID, "")
+		return
+	}
+
+	if handleChecklistCallback(bot, conv, messageID, callbackQuery.Data) {
+		return
+	}
+
+	if handleLanguageCallback(bot, conv, messageID, callbackQuery.Data) {
+		return
+	}
+
+	currentQuestion = surveyService.GetCurrentQuestion(key)
 	if currentQuestion == nil {
-		err := errors.New("currentQuestion == nil")
-		log.Println(err)
+		sessionExpired(bot, conv, callbackQuery)
+		return
+	}
+
+	if currentQuestion.IsMulti() {
+		handleMultiSelect(bot, conv, callbackQuery, currentQuestion)
 		return
 	}
 
@@ -68,49 +124,167 @@ func HandleCallbackQuery(bot BotInterface, callbackQuery *tgbotapi.CallbackQuery
 			continue
 		}
 
-		if option.IsTerminal() {
-			sendResults(bot, surveyService.GetLastMessageID(chatID), chatID, option.Data, option.Result)
-			surveyService.Reset(chatID)
+		if err := surveyService.SaveAnswer(key, currentQuestion.ID, option.Data); err != nil {
+			log.Println(err)
 			return
 		}
-
-		if nextQuestion := option.GetNextQuestion(); nextQuestion != nil {
-			err := surveyService.SaveQuestionToStack(chatID, currentQuestion)
-			if err != nil {
-				log.Println(err)
-				return
-			}
-
-			if err = surveyService.SetCurrentQuestion(chatID, nextQuestion); err != nil {
-				log.Println(err)
-				return
-			}
-			editQuestion(bot, chatID, surveyService.GetLastMessageID(chatID), nextQuestion)
+		if applyOption(bot, conv, key, currentQuestion, &option) {
 			return
 		}
 	}
 
-	callback := tgbotapi.NewCallback(callbackQuery.ID, "")
-	if _, err := bot.Request(callback); err != nil {
-		log.Println(err)
+	answerCallback(bot, callbackQuery.ID, "")
+}
+
+// Ответ на нажатие кнопки опроса без сессии: она удалена по времени бездействия
+// или потеряна при перезапуске бота. Сообщение с устаревшими кнопками заменяется предложением начать заново
+func sessionExpired(bot BotInterface, conv conversation, callbackQuery *tgbotapi.CallbackQuery) {
+	log.Println("session expired:", conv.ChatID, conv.UserID)
+
+	editSessionExpired(bot, conv, callbackQuery.Message.MessageID)
+	answerCallback(bot, callbackQuery.ID, i18n.T(conv.lang(), "Сессия устарела, начните заново"))
+}
+
+// Замена сообщения с устаревшими кнопками на предложение начать опрос заново
+func editSessionExpired(bot BotInterface, conv conversation, messageID int) {
+	lang := conv.lang()
+
+	editMsg := tgbotapi.NewEditMessageTextAndMarkup(
+		conv.ChatID,
+		messageID,
+		i18n.T(lang, "Сессия устарела, начните заново"),
+		tgbotapi.NewInlineKeyboardMarkup(restartKeyboardRow(lang)),
+	)
+	if _, err := bot.Send(editMsg); err != nil {
+		log.Println("Error editing expired session message:", err)
 	}
 }
 
+// Переход по выбранному варианту ответа: к итогу или к следующему вопросу.
+// Подходящее правило по ответам сессии заменяет переход варианта.
+// Для сессии без сообщения (key.MessageID == 0) вместо редактирования отправляется новое сообщение
+func applyOption(
+	bot BotInterface,
+	conv conversation,
+	key service.SessionKey,
+	currentQuestion *service.Question,
+	option *service.Option,
+) bool {
+	surveyService := service.GetInstance()
+
+	if rule := currentQuestion.MatchRule(surveyService.GetAnswers(key)); rule != nil {
+		option = &rule.Option
+	}
+
+	if option.IsTerminal() {
+		sendResults(bot, key.MessageID, conv, surveyService.GetSurvey(key), option)
+		surveyService.Reset(key)
+		return true
+	}
+
+	if nextQuestion := option.GetNextQuestion(); nextQuestion != nil {
+		err := surveyService.SaveQuestionToStack(key, currentQuestion)
+		if err != nil {
+			log.Println(err)
+			return true
+		}
+
+		if err = surveyService.SetCurrentQuestion(key, nextQuestion); err != nil {
+			log.Println(err)
+			return true
+		}
+
+		if key.MessageID == 0 {
+			sendQuestion(bot, conv, key, *nextQuestion)
+		} else {
+			editQuestion(bot, conv, key, nextQuestion)
+		}
+		return true
+	}
+
+	return false
+}
+
 // HandleMessage Обработка текстового сообщения
 func HandleMessage(bot BotInterface, message *tgbotapi.Message) {
-	if message.Command() == "start" {
+	// Группа преобразована в супергруппу: сессии продолжаются под новым ID чата.
+	// Telegram присылает оба служебных сообщения, повторный перенос ничего не меняет
+	if message.MigrateToChatID != 0 {
+		service.GetInstance().MigrateChat(message.Chat.ID, message.MigrateToChatID)
+		return
+	}
+	if message.MigrateFromChatID != 0 {
+		service.GetInstance().MigrateChat(message.MigrateFromChatID, message.Chat.ID)
+		return
+	}
+
+	conv := newConversation(message.Chat, message.From, message.MessageID)
+	detectLanguage(message.From)
+
+	if message.Location != nil {
+		handleLocation(bot, conv, message)
+		return
+	}
+
+	switch message.Command() {
+	case "start":
 		surveyService := service.GetInstance()
-		surveyService.Start(message.Chat.ID)
-		sendQuestion(bot, message.Chat.ID, *surveyService.GetCurrentQuestion(message.Chat.ID))
+		key := conv.session(0)
+		surveyService.Start(key)
+		question := surveyService.GetCurrentQuestion(key)
+		if question == nil {
+			log.Println("first question not found:", conv.ChatID, conv.UserID)
+			return
+		}
+		sendQuestion(bot, conv, key, *question)
+	case "reload":
+		if message.From == nil || !config.IsAdmin(message.From.ID) {
+			return
+		}
+		reloadSurvey(bot, conv)
+	case "language":
+		handleLanguageCommand(bot, conv, message)
+	case "search":
+		handleSearchCommand(bot, conv, message)
+	case "":
+		if handleLocationCancel(bot, conv, message) {
+			return
+		}
+		handleNumberAnswer(bot, conv, message)
+	}
+}
+
+// Перечитывание опросника по команде администратора
+func reloadSurvey(bot BotInterface, conv conversation) {
+	lang := conv.lang()
+	text := i18n.T(lang, "Опросник обновлен")
+
+	problems, err := service.ReloadSurvey(config.GetSurveyPath())
+	if err != nil {
+		log.Println("Error reloading survey:", err)
+		text = i18n.T(lang, "Ошибка обновления опросника: %v", err)
+	} else if len(problems) > 0 {
+		log.Println("Survey reloaded with problems:", problems)
+		text += i18n.T(lang, ", найдено ошибок: %d (см. cmd/treecheck)", len(problems))
 	}
+
+	sendText(bot, conv, text)
 }
 
 // Универсальная функция для отправки вопроса
-func sendQuestion(bot BotInterface, chatID int64, question service.Question) {
-	keyboard := createKeyboard(&question, chatID)
+func sendQuestion(bot BotInterface, conv conversation, key service.SessionKey, question service.Question) {
+	sendQuestionText(bot, conv, key, &question, questionText(&question, conv.lang()))
+}
 
-	msg := tgbotapi.NewMessage(chatID, question.Text)
-	msg.ReplyMarkup = keyboard
+// Отправка вопроса с произвольным текстом (например, с предупреждением о неверном вводе).
+// Сессия переносится в отправленное сообщение: дальше опрос идет в нем
+func sendQuestionText(bot BotInterface, conv conversation, key service.SessionKey, question *service.Question, text string) {
+	keyboard := createKeyboard(question, key, conv.lang())
+
+	msg := conv.newMessage(text)
+	if len(keyboard.InlineKeyboard) > 0 {
+		msg.ReplyMarkup = keyboard
+	}
 	sentMsg, err := bot.Send(msg)
 	if err != nil {
 		log.Println("Error sending message:", err)
@@ -118,19 +292,25 @@ func sendQuestion(bot BotInterface, chatID int64, question service.Question) {
 	}
 
 	surveyService := service.GetInstance()
-	surveyService.SetLastMessageID(chatID, sentMsg.MessageID)
+	surveyService.MoveSession(key, conv.session(sentMsg.MessageID))
+	surveyService.SetLastMessageID(conv.ChatUser, sentMsg.MessageID)
 }
 
-// Универсальная функция для редактирования вопроса
-func editQuestion(bot BotInterface, chatID int64, messageID int, question *service.Question) {
-	keyboard := createKeyboard(question, chatID)
+// Универсальная функция для редактирования вопроса в сообщении сессии
+func editQuestion(bot BotInterface, conv conversation, key service.SessionKey, question *service.Question) {
+	lang := conv.lang()
+	keyboard := createKeyboard(question, key, lang)
 
 	editMsg := tgbotapi.NewEditMessageTextAndMarkup(
-		chatID,
-		messageID,
-		question.Text,
+		conv.ChatID,
+		key.MessageID,
+		questionText(question, lang),
 		keyboard,
 	)
+	if len(keyboard.InlineKeyboard) == 0 {
+		// У вопроса с вводом числа без истории нет кнопок
+		editMsg.ReplyMarkup = nil
+	}
 
 	if _, err := bot.Send(editMsg); err != nil {
 		log.Println("Error editing message:", err)
@@ -138,55 +318,61 @@ func editQuestion(bot BotInterface, chatID int64, messageID int, question *servi
 }
 
 // Создание клавиатуры с кнопками
-func createKeyboard(question *service.Question, chatID int64) tgbotapi.InlineKeyboardMarkup {
+func createKeyboard(question *service.Question, key service.SessionKey, lang string) tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
-	// Кнопки вариантов ответа
-	for _, option := range question.Options {
-		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(option.Text, option.Data),
-		))
+	surveyService := service.GetInstance()
+	survey := surveyService.GetSurvey(key)
+	now := time.Now()
+
+	if question.IsMulti() {
+		// Варианты множественного выбора сами никуда не ведут и показываются все
+		rows = multiSelectRows(question, key, lang)
+	} else {
+		// Кнопки вариантов ответа, кроме ведущих только к закрытым исследованиям
+		for i := range question.Options {
+			option := &question.Options[i]
+			if !survey.IsOptionAvailable(option, now) {
+				continue
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(option.TextIn(lang), option.Data),
+			))
+		}
 	}
 
 	// Кнопка "Назад" если есть куда возвращаться
-	surveyService := service.GetInstance()
-	currentQuestion := surveyService.GetCurrentQuestion(chatID)
-	if currentQuestion != nil && len(surveyService.GetQuestionsStack(chatID)) > 0 {
+	currentQuestion := surveyService.GetCurrentQuestion(key)
+	if currentQuestion != nil && len(surveyService.GetQuestionsStack(key)) > 0 {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "Назад"), service.CallbackBack),
 		))
 	}
 
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
-// Отправка итогового результата с историей ответов
+// Отправка итогового результата: карточка исследования или список, если подходит несколько
+// или набор в исследование сейчас не идет
 func sendResults(
 	bot BotInterface,
 	messageID int,
-	chatID int64,
-	resultOption string,
-	result string,
+	conv conversation,
+	survey *service.Survey,
+	option *service.Option,
 ) {
-	messageText := fmt.Sprintf("✅ *Подходящее исследование:* %s", result)
-	messageText += "\n\n" + service.ResponseDescriptions[resultOption]
-
-	// Создаем inline-кнопку "Начать заново"
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("🔄 Начать заново", "start"),
-		),
-	)
-
-	editMsg := tgbotapi.NewEditMessageTextAndMarkup(
-		chatID,
-		messageID,
-		helper.EscapeMarkdownV2(messageText),
-		keyboard,
-	)
-	editMsg.ParseMode = "MarkdownV2"
-
-	if _, err := bot.Send(editMsg); err != nil {
-		log.Println("Error sending results:", err)
+	if len(option.Trials) == 1 {
+		trial := survey.GetTrial(option.Trials[0])
+		if trial == nil {
+			log.Println("trial not found in registry:", option.Trials[0])
+			return
+		}
+		if trial.IsRecruiting(time.Now()) {
+			sendTrialCard(bot, messageID, conv, trial, trialCard{cardData: service.CallbackTrialPrefix + trial.Code})
+			return
+		}
 	}
+
+	// Несколько исследований или набор не идет: список открытых с пометками о закрытых
+	sendTrialList(bot, messageID, conv, survey, option)
 }
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -26,6 +28,16 @@ func (m *MockBot) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
 	return args.Get(0).(*tgbotapi.APIResponse), args.Error(1)
 }
 
+// privateUser Пользователь в личном чате с ботом
+func privateUser(userID int64) service.ChatUser {
+	return service.ChatUser{ChatID: userID, UserID: userID}
+}
+
+// privateSession Ключ сессии опроса в сообщении messageID личного чата пользователя
+func privateSession(userID int64, messageID int) service.SessionKey {
+	return service.SessionKey{ChatUser: privateUser(userID), MessageID: messageID}
+}
+
 func TestConcurrentUsers(t *testing.T) {
 	var (
 		userID, numUsers int
@@ -116,7 +128,7 @@ func TestSingleUserFlow(t *testing.T) {
 	})
 
 	expectedQuestion := service.Questions[0].Options[2].NextQuestion
-	actualQuestion := surveyService.GetCurrentQuestion(int64(userID))
+	actualQuestion := surveyService.GetCurrentQuestion(privateSession(int64(userID), messageID))
 	assert.Equal(t, expectedQuestion, actualQuestion, "Ожидался следующий вопрос после выбора q1_option3")
 
 	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
@@ -131,7 +143,7 @@ func TestSingleUserFlow(t *testing.T) {
 	mockBot.AssertNumberOfCalls(t, "Send", 3)
 
 	// Очищаем состояние после теста
-	surveyService.Reset(int64(userID))
+	surveyService.Reset(privateSession(int64(userID), messageID))
 }
 
 func TestSurveyWithStack(t *testing.T) {
@@ -147,6 +159,17 @@ func TestSurveyWithStack(t *testing.T) {
 	userID = 101
 	messageID = 1 // постаянно его редактируем
 
+	// дожидаемся параллельных пользователей, чтобы они не пересекались со следующими тестами
+	var concurrentUsers sync.WaitGroup
+	defer concurrentUsers.Wait()
+	imitate := func(messageID, userID int) {
+		concurrentUsers.Add(1)
+		go func() {
+			defer concurrentUsers.Done()
+			imitateConcurrentUser(messageID, userID)
+		}()
+	}
+
 	messageMock = tgbotapi.Message{MessageID: messageID, Chat: &tgbotapi.Chat{ID: int64(userID)}}
 
 	mock.InOrder(
@@ -182,7 +205,7 @@ func TestSurveyWithStack(t *testing.T) {
 	})
 
 	// подмешаем парарельно еще 1 пользователя
-	go imitateConcurrentUser(2, 102)
+	imitate(2, 102)
 
 	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
 		ID:      "callback_id",
@@ -220,7 +243,7 @@ func TestSurveyWithStack(t *testing.T) {
 	})
 
 	// подмешаем парарельно еще 1 пользователя
-	go imitateConcurrentUser(3, 103)
+	imitate(3, 103)
 
 	// промежуточная проверка
 	assertionsForStackTesting(
@@ -245,7 +268,7 @@ func TestSurveyWithStack(t *testing.T) {
 	})
 
 	// подмешаем парарельно еще 1 пользователя
-	go imitateConcurrentUser(4, 104)
+	imitate(4, 104)
 
 	assertionsForStackTesting(
 		t,
@@ -258,7 +281,7 @@ func TestSurveyWithStack(t *testing.T) {
 	mockBot.AssertExpectations(t)
 
 	// Очищаем состояние после теста
-	surveyService.Reset(int64(userID))
+	surveyService.Reset(privateSession(int64(userID), messageID))
 }
 
 func imitateConcurrentUser(messageID, userID int) {
@@ -302,12 +325,1028 @@ func assertionsForStackTesting(
 	expectedQuestion *service.Question,
 ) {
 	surveyService := service.GetInstance()
-	stackLen := len(surveyService.GetQuestionsStack(userID))
+	key := privateSession(userID, messageID)
+	stackLen := len(surveyService.GetQuestionsStack(key))
 	assert.Equal(t, expectedStackLen, stackLen, "Проверка кол-во вопросов в стеке")
 
-	actualQuestion := surveyService.GetCurrentQuestion(userID)
+	actualQuestion := surveyService.GetCurrentQuestion(key)
 	assert.Equal(t, expectedQuestion, actualQuestion, "Ожидался другой следующий вопрос")
 
-	lastMessageID := surveyService.GetLastMessageID(userID)
+	lastMessageID := surveyService.GetLastMessageID(key.ChatUser)
 	assert.Equal(t, messageID, lastMessageID, "Проверка lastMessageID")
 }
+
+// test case: ID q1 -> Data q1_option1 -> список из 2 исследований -> карточка -> назад к списку
+func TestMultipleTrialsFlow(t *testing.T) {
+	var (
+		userID      int64
+		mockBot     *MockBot
+		messageMock tgbotapi.Message
+	)
+
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак легкого", Data: "q1_option1", Trials: []string{"MIT-002", "BEV-III/2022"}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 201
+	messageMock = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+
+	isTrialList := func(msg tgbotapi.EditMessageTextConfig) bool {
+		markup := msg.ReplyMarkup.InlineKeyboard
+		return strings.Contains(msg.Text, "Подходящие исследования") &&
+			len(markup) == 3 &&
+			markup[0][0].Text == "MIT-002" &&
+			*markup[1][0].CallbackData == "results:q1_option1:BEV-III/2022"
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(isTrialList)).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование:* BEV\\-III/2022") &&
+				*msg.ReplyMarkup.InlineKeyboard[1][0].CallbackData == "results:q1_option1"
+		})).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(isTrialList)).Return(messageMock, nil).Once(),
+		// После обновления опросника кнопка из старого списка открывает то же исследование
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование:* BEV\\-III/2022")
+		})).Return(messageMock, nil).Once(),
+	)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: userID},
+		Text: "/start",
+		Entities: []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: 6},
+		},
+	})
+	tap := func(data string) {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+			ID:      "callback_id",
+			From:    &tgbotapi.User{ID: userID},
+			Message: &messageMock,
+			Data:    data,
+		})
+	}
+	for _, data := range []string{"q1_option1", "results:q1_option1:BEV-III/2022", "results:q1_option1"} {
+		tap(data)
+	}
+
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак легкого", Data: "q1_option1", Trials: []string{"BEV-III/2022", "MIT-002"}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	tap("results:q1_option1:BEV-III/2022")
+
+	mockBot.AssertExpectations(t)
+}
+
+// test case: карточка RB-012 -> проверка критериев -> "Нет" на первый критерий включения -> итог "не подходит"
+func TestChecklistFlow(t *testing.T) {
+	var (
+		userID      int64
+		mockBot     *MockBot
+		messageMock tgbotapi.Message
+	)
+
+	mockBot = new(MockBot)
+	userID = 301
+	messageMock = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	trial := service.DefaultSurvey().GetTrial("RB-012")
+
+	isStep := func(title string) func(msg tgbotapi.EditMessageTextConfig) bool {
+		return func(msg tgbotapi.EditMessageTextConfig) bool {
+			return strings.Contains(msg.Text, "Проверка критериев") && strings.Contains(msg.Text, title)
+		}
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий включения 1 из 4"))).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий включения 2 из 4"))).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий включения 1 из 4"))).Return(messageMock, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий включения 2 из 4"))).Return(messageMock, nil).Once(),
+	)
+	for range len(trial.Inclusion) - 2 {
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий включения"))).Return(messageMock, nil).Once()
+	}
+	for range trial.Exclusion {
+		mockBot.On("Send", mock.MatchedBy(isStep("Критерий невключения"))).Return(messageMock, nil).Once()
+	}
+	mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+		return strings.Contains(msg.Text, "Пациент не подходит") &&
+			strings.Contains(msg.Text, "включения\\) Гистологически верифицированная")
+	})).Return(messageMock, nil).Once()
+
+	press := func(data string) {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+			ID:      "check_callback",
+			From:    &tgbotapi.User{ID: userID},
+			Message: &messageMock,
+			Data:    data,
+		})
+	}
+
+	press(service.CallbackCheckPrefix + trial.Code)
+	press(service.CallbackCheckYes)
+	press(service.CallbackCheckBack)
+	press(service.CallbackCheckNo)
+	for range len(trial.Inclusion) - 1 {
+		press(service.CallbackCheckYes)
+	}
+	for range trial.Exclusion {
+		press(service.CallbackCheckUnknown)
+	}
+
+	mockBot.AssertExpectations(t)
+	service.GetInstance().Reset(privateSession(userID, messageMock.MessageID))
+}
+
+// test case: вопрос с вводом числа -> неверный ввод -> повторный вопрос -> число -> итог
+func TestNumberQuestionFlow(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	minECOG, maxECOG, maxGood := 0.0, 4.0, 1.0
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак желудка", Data: "q1_option1", NextQuestion: &service.Question{
+						ID:    "q1_1",
+						Text:  "Укажите балл ECOG",
+						Type:  service.QuestionNumber,
+						Input: &service.NumberInput{Min: &minECOG, Max: &maxECOG, Integer: true},
+						Routes: []service.Route{
+							{Max: &maxGood, Option: service.Option{Data: "q1_1_ecog_good", Trials: []string{"RB-012"}}},
+						},
+					}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 401
+	question := &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	reprompt := tgbotapi.Message{MessageID: 2, Chat: &tgbotapi.Chat{ID: userID}}
+	results := tgbotapi.Message{MessageID: 3, Chat: &tgbotapi.Chat{ID: userID}}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.Text == "Укажите балл ECOG\n\nВведите целое число от 0 до 4" &&
+				*msg.ReplyMarkup.InlineKeyboard[0][0].CallbackData == service.CallbackBack
+		})).Return(*question, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.HasPrefix(msg.Text, "⚠️ Нужно ввести целое число.")
+		})).Return(reprompt, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.HasPrefix(msg.Text, "⚠️ Для этого значения нет продолжения опроса.")
+		})).Return(reprompt, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование") && msg.ParseMode == "MarkdownV2"
+		})).Return(results, nil).Once(),
+	)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: userID},
+		Text: "/start",
+		Entities: []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: 6},
+		},
+	})
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID:      "callback_id",
+		From:    &tgbotapi.User{ID: userID},
+		Message: question,
+		Data:    "q1_option1",
+	})
+	for _, text := range []string{"1,5", "3", "1"} {
+		HandleMessage(mockBot, &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: userID}, Text: text})
+	}
+
+	mockBot.AssertExpectations(t)
+	assert.Nil(t, service.GetInstance().GetCurrentQuestion(privateSession(userID, reprompt.MessageID)), "Сессия завершена после итога")
+	assert.Equal(t, results.MessageID, service.GetInstance().GetLastMessageID(privateUser(userID)))
+}
+
+func TestGroupNumberAnswer(t *testing.T) {
+	var (
+		groupID  int64 = -1700
+		alice          = &tgbotapi.User{ID: 1701}
+		group          = &tgbotapi.Chat{ID: groupID, Type: "group"}
+		question       = tgbotapi.Message{MessageID: 21, Chat: group}
+		mockBot        = new(MockBot)
+	)
+
+	minECOG, maxECOG, maxGood := 0.0, 4.0, 1.0
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак желудка", Data: "q1_option1", NextQuestion: &service.Question{
+						ID:    "q1_1",
+						Text:  "Укажите балл ECOG",
+						Type:  service.QuestionNumber,
+						Input: &service.NumberInput{Min: &minECOG, Max: &maxECOG, Integer: true},
+						Routes: []service.Route{
+							{Max: &maxGood, Option: service.Option{Data: "q1_1_ecog_good", Trials: []string{"RB-012"}}},
+						},
+					}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	// Переписка в группе не считается ответом: бот отвечает только на ответ к сообщению с вопросом
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(question, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.MessageID == question.MessageID && strings.HasPrefix(msg.Text, "Укажите балл ECOG")
+		})).Return(question, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование") && msg.ReplyToMessageID == 24
+		})).Return(tgbotapi.Message{MessageID: 25, Chat: group}, nil).Once(),
+	)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		MessageID: 20,
+		From:      alice,
+		Chat:      group,
+		Text:      "/start",
+		Entities:  []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 6}},
+	})
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: "alice", From: alice, Message: &question, Data: "q1_option1"})
+	HandleMessage(mockBot, &tgbotapi.Message{MessageID: 22, From: alice, Chat: group, Text: "3 дня назад"})
+	HandleMessage(mockBot, &tgbotapi.Message{MessageID: 23, From: alice, Chat: group, Text: "1",
+		ReplyToMessage: &tgbotapi.Message{MessageID: 19, Chat: group}})
+	HandleMessage(mockBot, &tgbotapi.Message{MessageID: 24, From: alice, Chat: group, Text: "1",
+		ReplyToMessage: &tgbotapi.Message{MessageID: question.MessageID, Chat: group}})
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestMultiSelectFlow(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак легкого", Data: "q1_option1", NextQuestion: &service.Question{
+						ID:   "q1_1",
+						Text: "Отметьте выявленные мутации",
+						Type: service.QuestionMulti,
+						Options: []service.Option{
+							{Text: "EGFR", Data: "q1_1_egfr"},
+							{Text: "ALK", Data: "q1_1_alk"},
+						},
+						Routes: []service.Route{
+							{All: []string{"q1_1_egfr"}, None: []string{"q1_1_alk"}, Option: service.Option{Data: "q1_1_egfr_only", Trials: []string{"AREAL"}}},
+						},
+					}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 501
+	question := &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+
+	keyboardTexts := func(msg tgbotapi.EditMessageTextConfig) (texts []string) {
+		for _, row := range msg.ReplyMarkup.InlineKeyboard {
+			texts = append(texts, row[0].Text)
+		}
+		return
+	}
+	editWithButtons := func(texts ...string) any {
+		return mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.Text == "Отметьте выявленные мутации" && assert.ObjectsAreEqual(texts, keyboardTexts(msg))
+		})
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithButtons("EGFR", "ALK", "Готово", "Назад")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithButtons("✅ EGFR", "ALK", "Готово", "Назад")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithButtons("✅ EGFR", "✅ ALK", "Готово", "Назад")).Return(*question, nil).Once(),
+		mockBot.On("Request", mock.MatchedBy(func(callback tgbotapi.CallbackConfig) bool {
+			return strings.HasPrefix(callback.Text, "Для выбранных вариантов нет продолжения")
+		})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		mockBot.On("Send", editWithButtons("✅ EGFR", "ALK", "Готово", "Назад")).Return(*question, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование") && strings.Contains(msg.Text, "AREAL")
+		})).Return(*question, nil).Once(),
+	)
+	mockBot.On("Request", mock.MatchedBy(func(callback tgbotapi.CallbackConfig) bool {
+		return callback.Text == ""
+	})).Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: userID},
+		Text: "/start",
+		Entities: []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: 6},
+		},
+	})
+	for _, data := range []string{"q1_option1", "q1_1_egfr", "q1_1_alk", service.CallbackDone, "q1_1_alk"} {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+			ID:      "callback_id",
+			From:    &tgbotapi.User{ID: userID},
+			Message: question,
+			Data:    data,
+		})
+	}
+	assert.Equal(t, []string{"q1_1_egfr"}, service.GetInstance().GetSelection(privateSession(userID, question.MessageID), "q1_1"))
+
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID:      "callback_id",
+		From:    &tgbotapi.User{ID: userID},
+		Message: question,
+		Data:    service.CallbackDone,
+	})
+
+	mockBot.AssertExpectations(t)
+	assert.Nil(t, service.GetInstance().GetCurrentQuestion(privateSession(userID, question.MessageID)), "Сессия завершена после итога")
+}
+
+func TestRuleRoutingFlow(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	line := &service.Question{
+		ID:     "q_line",
+		Text:   "Укажите линию терапии",
+		Type:   service.QuestionNumber,
+		Input:  &service.NumberInput{Integer: true},
+		Routes: []service.Route{{Option: service.Option{Data: "q_line_any", Trials: []string{"RB-012"}}}},
+		Rules: []service.Rule{
+			{When: "q1 = q1_option1 and q_line >= 2", Option: service.Option{Data: "q_line_lung_second", Trials: []string{"AREAL"}}},
+		},
+	}
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []service.Option{
+					{Text: "Рак легкого", Data: "q1_option1", NextQuestion: line},
+					{Text: "Рак желудка", Data: "q1_option2", NextQuestion: line},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 601
+	question := &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	results := tgbotapi.Message{MessageID: 2, Chat: &tgbotapi.Chat{ID: userID}}
+	resultWith := func(code string) any {
+		return mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.Contains(msg.Text, "Подходящее исследование") && strings.Contains(msg.Text, code)
+		})
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.EditMessageTextConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", resultWith("AREAL")).Return(results, nil).Once(),
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.EditMessageTextConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", resultWith(`RB\-012`)).Return(results, nil).Once(),
+	)
+
+	// Один и тот же ответ о линии терапии ведет к разным исследованиям в зависимости от нозологии
+	for _, nosology := range []string{"q1_option1", "q1_option2"} {
+		HandleMessage(mockBot, &tgbotapi.Message{
+			Chat: &tgbotapi.Chat{ID: userID},
+			Text: "/start",
+			Entities: []tgbotapi.MessageEntity{
+				{Type: "bot_command", Offset: 0, Length: 6},
+			},
+		})
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+			ID:      "callback_id",
+			From:    &tgbotapi.User{ID: userID},
+			Message: question,
+			Data:    nosology,
+		})
+		HandleMessage(mockBot, &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: userID}, Text: "2"})
+	}
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestSharedQuestionBackNavigation(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	path := filepath.Join(t.TempDir(), "survey.yaml")
+	err := os.WriteFile(path, []byte(`
+questions:
+  - id: q1
+    text: Выберите нозологию
+    options:
+      - text: Рак легкого
+        data: q1_lung
+        next_question:
+          id: q1_1
+          text: Выберите профиль
+          options: [{text: EGFR, data: q1_1_egfr, next: line}]
+      - {text: Рак желудка, data: q1_gastric, next: line}
+  - id: line
+    text: Укажите линию терапии
+    options: [{text: 2 линия, data: line_second, trials: [RB-012]}]
+`), 0o600)
+	if !assert.NoError(t, err) {
+		return
+	}
+	survey, err := service.LoadSurvey(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	survey.Trials = service.Trials
+	service.UseSurvey(survey)
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 701
+	question := &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	editWithText := func(text string) any {
+		return mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.Text == text
+		})
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.AnythingOfType("tgbotapi.MessageConfig")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Выберите профиль")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Укажите линию терапии")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Выберите профиль")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Выберите нозологию")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Укажите линию терапии")).Return(*question, nil).Once(),
+		mockBot.On("Send", editWithText("Выберите нозологию")).Return(*question, nil).Once(),
+	)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: userID},
+		Text: "/start",
+		Entities: []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: 6},
+		},
+	})
+	// Общий вопрос возвращает "Назад" в ту ветку, из которой пришел пользователь
+	for _, data := range []string{"q1_lung", "q1_1_egfr", service.CallbackBack, service.CallbackBack, "q1_gastric", service.CallbackBack} {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+			ID:      "callback_id",
+			From:    &tgbotapi.User{ID: userID},
+			Message: question,
+			Data:    data,
+		})
+	}
+
+	mockBot.AssertExpectations(t)
+	service.GetInstance().Reset(privateSession(userID, question.MessageID))
+}
+
+func TestLanguageSelection(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	service.UseSurvey(&service.Survey{
+		Questions: []service.Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				I18n: service.Translations{"en": "Choose the cancer type"},
+				Options: []service.Option{
+					{Text: "Меланома", Data: "q1_option1", I18n: service.Translations{"en": "Melanoma"}, Trials: []string{"MIT-002"}},
+				},
+			},
+		},
+		Trials: service.Trials,
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot = new(MockBot)
+	userID = 801
+	message := tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	command := func(text string) *tgbotapi.Message {
+		return &tgbotapi.Message{
+			Chat:     &tgbotapi.Chat{ID: userID},
+			From:     &tgbotapi.User{ID: userID, LanguageCode: "en-GB"},
+			Text:     text,
+			Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: strings.Index(text+" ", " ")}},
+		}
+	}
+
+	mock.InOrder(
+		// Язык определен по настройкам Telegram
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			keyboard := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+			return msg.Text == "Choose the cancer type" && keyboard.InlineKeyboard[0][0].Text == "Melanoma"
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			keyboard := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+			return msg.Text == "Choose the interface language" &&
+				*keyboard.InlineKeyboard[0][0].CallbackData == service.CallbackLanguagePrefix+"ru"
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.Text == "Язык интерфейса: Русский"
+		})).Return(message, nil).Once(),
+		// Выбранный язык важнее настроек Telegram
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return msg.Text == "Выберите нозологию"
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return msg.Text == "Interface language: English"
+		})).Return(message, nil).Once(),
+	)
+
+	HandleMessage(mockBot, command("/start"))
+	HandleMessage(mockBot, command("/language"))
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID:      "callback_id",
+		From:    &tgbotapi.User{ID: userID, LanguageCode: "en"},
+		Message: &message,
+		Data:    service.CallbackLanguagePrefix + "ru",
+	})
+	HandleMessage(mockBot, command("/start"))
+	HandleMessage(mockBot, command("/language en"))
+
+	mockBot.AssertExpectations(t)
+	assert.Equal(t, "en", service.GetInstance().GetLanguage(userID))
+	service.GetInstance().Reset(privateSession(userID, message.MessageID))
+}
+
+func TestSearchCommand(t *testing.T) {
+	var (
+		userID  int64
+		mockBot *MockBot
+	)
+
+	mockBot = new(MockBot)
+	userID = 901
+	message := tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	command := func(text string) *tgbotapi.Message {
+		return &tgbotapi.Message{
+			Chat:     &tgbotapi.Chat{ID: userID},
+			Text:     text,
+			Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len("/search")}},
+		}
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return strings.HasPrefix(msg.Text, "Введите запрос")
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			keyboard := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+			return msg.ParseMode == "MarkdownV2" && strings.Contains(msg.Text, "Найдено по запросу «меланома»") &&
+				*keyboard.InlineKeyboard[0][0].CallbackData == service.CallbackTrialPrefix+"MIT-002"
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.MessageID == message.MessageID && strings.Contains(msg.Text, `MIT\-002`)
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return msg.Text == "По запросу «глиобластома» ничего не найдено"
+		})).Return(message, nil).Once(),
+	)
+
+	HandleMessage(mockBot, command("/search"))
+	HandleMessage(mockBot, command("/search меланома"))
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID:      "callback_id",
+		From:    &tgbotapi.User{ID: userID},
+		Message: &message,
+		Data:    service.CallbackTrialPrefix + "MIT-002",
+	})
+	HandleMessage(mockBot, command("/search глиобластома"))
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestInlineQuery(t *testing.T) {
+	mockBot := new(MockBot)
+
+	articles := func(c tgbotapi.InlineConfig) (result []tgbotapi.InlineQueryResultArticle) {
+		for _, item := range c.Results {
+			result = append(result, item.(tgbotapi.InlineQueryResultArticle))
+		}
+		return
+	}
+
+	mock.InOrder(
+		mockBot.On("Request", mock.MatchedBy(func(c tgbotapi.InlineConfig) bool {
+			results := articles(c)
+			content := results[0].InputMessageContent.(tgbotapi.InputTextMessageContent)
+			return c.InlineQueryID == "query_1" && len(results) == 1 && results[0].ID == "MIT-002" &&
+				content.ParseMode == "MarkdownV2" && strings.Contains(content.Text, "Inclusion criteria")
+		})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		mockBot.On("Request", mock.MatchedBy(func(c tgbotapi.InlineConfig) bool {
+			return c.InlineQueryID == "query_2" && len(articles(c)) == len(service.Trials)
+		})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+	)
+
+	HandleInlineQuery(mockBot, &tgbotapi.InlineQuery{
+		ID:    "query_1",
+		From:  &tgbotapi.User{ID: 1001, LanguageCode: "en"},
+		Query: "меланома",
+	})
+	HandleInlineQuery(mockBot, &tgbotapi.InlineQuery{
+		ID:   "query_2",
+		From: &tgbotapi.User{ID: 1002},
+	})
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestCoordinatorContacts(t *testing.T) {
+	var (
+		userID  int64 = 1101
+		mockBot       = new(MockBot)
+		message       = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	)
+
+	service.UseSurvey(&service.Survey{
+		Questions: service.Questions,
+		Trials: []service.Trial{{
+			Code:      "SITE-1",
+			Title:     "Исследование с центрами",
+			Inclusion: []string{"Критерий"},
+			Sites: []service.Site{
+				{Name: "НМИЦ онкологии", City: "Москва", Address: "Каширское ш., 23", Investigator: "Петров П. П.", Phone: "+7 495 000-00-00", Telegram: "@coord_lung"},
+				{Name: "НМИЦ онкологии", City: "Москва", Telegram: "https://t.me/coord_lung"},
+				{Name: "Онкодиспансер", City: "Казань", Telegram: "bad name"},
+			},
+		}},
+	})
+	defer service.UseSurvey(nil)
+
+	mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+		keyboard := msg.ReplyMarkup
+		var urls []string
+		for _, row := range keyboard.InlineKeyboard {
+			if row[0].URL != nil {
+				urls = append(urls, *row[0].URL)
+			}
+		}
+		return strings.Contains(msg.Text, `Каширское ш\., 23`) &&
+			strings.Contains(msg.Text, `Главный исследователь: Петров П\. П\.`) &&
+			strings.Contains(msg.Text, `Координатор: \+7 495 000\-00\-00, @coord\_lung`) &&
+			!strings.Contains(msg.Text, "bad name") &&
+			len(urls) == 1 && urls[0] == "https://t.me/coord_lung"
+	})).Return(message, nil).Once()
+
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID:      "callback_id",
+		From:    &tgbotapi.User{ID: userID},
+		Message: &message,
+		Data:    service.CallbackTrialPrefix + "SITE-1",
+	})
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestNearestSiteFlow(t *testing.T) {
+	var (
+		userID  int64 = 1201
+		mockBot       = new(MockBot)
+		message       = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+	)
+
+	service.UseSurvey(&service.Survey{
+		Questions: service.Questions,
+		Trials: []service.Trial{{
+			Code:      "GEO-1",
+			Title:     "Исследование в трех городах",
+			Inclusion: []string{"Критерий"},
+			Sites: []service.Site{
+				{Name: "Центр Москва", City: "Москва", Lat: 55.75, Lon: 37.62},
+				{Name: "Центр Без координат", City: "Самара"},
+				{Name: "Центр Казань", City: "Казань", Lat: 55.79, Lon: 49.12, Telegram: "coord_kazan"},
+				{Name: "Центр Петербург", City: "Санкт-Петербург", Lat: 59.93, Lon: 30.34},
+			},
+		}},
+	})
+	defer service.UseSurvey(nil)
+	defer service.GetInstance().SetLocationRequest(privateUser(userID), "")
+
+	sendLocation := func() {
+		HandleMessage(mockBot, &tgbotapi.Message{
+			Chat:     &tgbotapi.Chat{ID: userID},
+			Location: &tgbotapi.Location{Latitude: 55.8, Longitude: 49.1}, // Казань
+		})
+	}
+	removesKeyboard := func(msg tgbotapi.MessageConfig) bool {
+		_, ok := msg.ReplyMarkup.(tgbotapi.ReplyKeyboardRemove)
+		return ok
+	}
+
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			keyboard := msg.ReplyMarkup.InlineKeyboard
+			return keyboard[1][0].CallbackData != nil && *keyboard[1][0].CallbackData == service.CallbackNearPrefix+"GEO-1"
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			keyboard, ok := msg.ReplyMarkup.(tgbotapi.ReplyKeyboardMarkup)
+			return ok && keyboard.Keyboard[0][0].RequestLocation && strings.Contains(msg.Text, "GEO-1")
+		})).Return(message, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			kazan := strings.Index(msg.Text, `1\. Центр Казань, Казань — 2 км`)
+			moscow := strings.Index(msg.Text, `2\. Центр Москва, Москва`)
+			return removesKeyboard(msg) && kazan >= 0 && moscow > kazan &&
+				strings.Contains(msg.Text, `Координатор: @coord\_kazan`) &&
+				strings.Contains(msg.Text, `3\. Центр Петербург`) && !strings.Contains(msg.Text, "Самара")
+		})).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return removesKeyboard(msg) && strings.HasPrefix(msg.Text, "Откройте карточку исследования")
+		})).Return(message, nil).Once(),
+	)
+
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID: "trial", From: &tgbotapi.User{ID: userID}, Message: &message, Data: service.CallbackTrialPrefix + "GEO-1",
+	})
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{
+		ID: "near", From: &tgbotapi.User{ID: userID}, Message: &message, Data: service.CallbackNearPrefix + "GEO-1",
+	})
+	sendLocation()
+	sendLocation() // Повторное местоположение без запроса
+
+	mockBot.AssertExpectations(t)
+	assert.Empty(t, service.GetInstance().GetLocationRequest(privateUser(userID)))
+}
+
+func TestLongTrialPagination(t *testing.T) {
+	var (
+		userID    int64 = 1301
+		mockBot         = new(MockBot)
+		message         = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+		inclusion []string
+	)
+	for i := 0; i < 60; i++ {
+		inclusion = append(inclusion, "Критерий с точками, дефисами и скобками (ECOG 0-1, доза 1.5 мг/кг) — "+strings.Repeat("текст ", 10))
+	}
+
+	service.UseSurvey(&service.Survey{
+		Questions: service.Questions,
+		Trials:    []service.Trial{{Code: "LONG-1", Title: "Длинное исследование", Inclusion: inclusion}},
+	})
+	defer service.UseSurvey(nil)
+
+	pageButtons := func(msg tgbotapi.EditMessageTextConfig) (buttons []string) {
+		for _, button := range msg.ReplyMarkup.InlineKeyboard[0] {
+			buttons = append(buttons, button.Text+" "+*button.CallbackData)
+		}
+		return
+	}
+	fits := func(msg tgbotapi.EditMessageTextConfig) bool {
+		return textLen(msg.Text) <= maxMessageLen && strings.HasPrefix(msg.Text, "✅ *Подходящее исследование:* LONG\\-1")
+	}
+
+	var texts []string
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			buttons := pageButtons(msg)
+			return fits(msg) && strings.HasSuffix(msg.Text, "_Страница 1 из 3_") &&
+				len(buttons) == 1 && buttons[0] == "▶ page:1:trial:LONG-1"
+		})).Run(func(args mock.Arguments) {
+			texts = append(texts, args.Get(0).(tgbotapi.EditMessageTextConfig).Text)
+		}).Return(message, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			buttons := pageButtons(msg)
+			return fits(msg) && strings.HasSuffix(msg.Text, "_Страница 2 из 3_") &&
+				len(buttons) == 2 && buttons[0] == "◀ page:0:trial:LONG-1" && buttons[1] == "▶ page:2:trial:LONG-1"
+		})).Run(func(args mock.Arguments) {
+			texts = append(texts, args.Get(0).(tgbotapi.EditMessageTextConfig).Text)
+		}).Return(message, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			buttons := pageButtons(msg)
+			return fits(msg) && strings.HasSuffix(msg.Text, "_Страница 3 из 3_") && len(buttons) == 1 && buttons[0] == "◀ page:1:trial:LONG-1"
+		})).Run(func(args mock.Arguments) {
+			texts = append(texts, args.Get(0).(tgbotapi.EditMessageTextConfig).Text)
+		}).Return(message, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+	)
+
+	tap := func(data string) {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: data, From: &tgbotapi.User{ID: userID}, Message: &message, Data: data})
+	}
+	tap(service.CallbackTrialPrefix + "LONG-1")
+	tap("page:1:trial:LONG-1")
+	tap("page:7:trial:LONG-1") // Номер страницы за пределами описания показывает последнюю страницу
+
+	mockBot.AssertExpectations(t)
+
+	// Каждый критерий целиком на одной из страниц
+	all := strings.Join(texts, "\n")
+	assert.Equal(t, len(inclusion), strings.Count(all, "• Критерий"))
+}
+
+func TestSendDocuments(t *testing.T) {
+	var (
+		userID    int64 = 1401
+		englishID int64 = 1402
+		mockBot         = new(MockBot)
+		message         = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: userID}}
+		english         = tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: englishID}}
+		file            = filepath.Join(t.TempDir(), "synopsis.pdf")
+	)
+	if err := os.WriteFile(file, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	service.UseSurvey(&service.Survey{
+		Questions: service.Questions,
+		Trials: []service.Trial{{
+			Code: "DOC-1", Title: "Исследование с синопсисом", Inclusion: []string{"Критерий"},
+			Documents: []service.Document{{File: file}},
+		}},
+	})
+	defer service.UseSurvey(nil)
+
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			for _, row := range msg.ReplyMarkup.InlineKeyboard {
+				for _, button := range row {
+					if button.Text == "📄 Синопсис" && *button.CallbackData == "doc:DOC-1" {
+						return true
+					}
+				}
+			}
+			return false
+		})).Return(message, nil).Once(),
+		// Первое нажатие загружает файл, Telegram возвращает его file_id
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.DocumentConfig) bool {
+			return msg.File == tgbotapi.FilePath(file) && msg.Caption == "📄 Синопсис протокола — DOC-1"
+		})).Return(tgbotapi.Message{Document: &tgbotapi.Document{FileID: "file-1"}}, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		// Повторное нажатие отправляет уже загруженный файл
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.DocumentConfig) bool {
+			return msg.File == tgbotapi.FileID("file-1")
+		})).Return(tgbotapi.Message{Document: &tgbotapi.Document{FileID: "file-1"}}, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		// Кнопка и подпись документа на языке пользователя
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			for _, row := range msg.ReplyMarkup.InlineKeyboard {
+				for _, button := range row {
+					if button.Text == "📄 Synopsis" && *button.CallbackData == "doc:DOC-1" {
+						return true
+					}
+				}
+			}
+			return false
+		})).Return(english, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.DocumentConfig) bool {
+			return msg.ChatID == englishID && msg.Caption == "📄 Protocol synopsis — DOC-1"
+		})).Return(tgbotapi.Message{Document: &tgbotapi.Document{FileID: "file-1"}}, nil).Once(),
+		mockBot.On("Request", mock.AnythingOfType("tgbotapi.CallbackConfig")).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+	)
+
+	tap := func(data string) {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: data, From: &tgbotapi.User{ID: userID}, Message: &message, Data: data})
+	}
+	tap(service.CallbackTrialPrefix + "DOC-1")
+	tap(service.CallbackDocPrefix + "DOC-1")
+	tap(service.CallbackDocPrefix + "DOC-1")
+
+	service.GetInstance().SetLanguage(englishID, "en")
+	for _, data := range []string{service.CallbackTrialPrefix + "DOC-1", service.CallbackDocPrefix + "DOC-1"} {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: data, From: &tgbotapi.User{ID: englishID}, Message: &english, Data: data})
+	}
+
+	mockBot.AssertExpectations(t)
+}
+
+func TestExpiredSessionTap(t *testing.T) {
+	var (
+		userID  int64 = 1501
+		mockBot       = new(MockBot)
+		message       = tgbotapi.Message{MessageID: 7, Chat: &tgbotapi.Chat{ID: userID}}
+	)
+
+	mock.InOrder(
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.MessageID == 7 && msg.Text == "Сессия устарела, начните заново" &&
+				*msg.ReplyMarkup.InlineKeyboard[0][0].CallbackData == service.CallbackStart
+		})).Return(message, nil).Once(),
+		mockBot.On("Request", mock.MatchedBy(func(msg tgbotapi.CallbackConfig) bool {
+			return msg.Text == "Сессия устарела, начните заново"
+		})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		// "Начать заново" начинает опрос в том же сообщении
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.MessageID == 7 && strings.Contains(msg.Text, service.Questions[0].Text)
+		})).Return(message, nil).Once(),
+	)
+
+	tap := func(data string) {
+		HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: data, From: &tgbotapi.User{ID: userID}, Message: &message, Data: data})
+	}
+	tap(service.Questions[0].Options[0].Data)
+	tap(service.CallbackStart)
+
+	mockBot.AssertExpectations(t)
+	assert.Equal(t, 7, service.GetInstance().GetLastMessageID(privateUser(userID)))
+	service.GetInstance().Reset(privateSession(userID, message.MessageID))
+}
+
+func TestGroupChat(t *testing.T) {
+	var (
+		groupID      int64 = -1600
+		supergroupID int64 = -1001600
+		alice              = &tgbotapi.User{ID: 1601}
+		bob                = &tgbotapi.User{ID: 1602}
+		group              = &tgbotapi.Chat{ID: groupID, Type: "group"}
+		supergroup         = &tgbotapi.Chat{ID: supergroupID, Type: "supergroup"}
+		question           = tgbotapi.Message{MessageID: 11, Chat: group}
+		mockBot            = new(MockBot)
+	)
+	first := service.Questions[0]
+	next := first.Options[0].NextQuestion
+
+	mock.InOrder(
+		// Вопрос отправляется ответом на команду, поэтому остается в теме форума
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.MessageConfig) bool {
+			return msg.ChatID == groupID && msg.ReplyToMessageID == 10 && msg.AllowSendingWithoutReply && msg.Text == first.Text
+		})).Return(question, nil).Once(),
+		// Чужой опрос не отвечает на нажатия
+		mockBot.On("Request", mock.MatchedBy(func(msg tgbotapi.CallbackConfig) bool {
+			return msg.CallbackQueryID == "bob" && strings.HasPrefix(msg.Text, "Это опрос другого участника")
+		})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once(),
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.ChatID == groupID && msg.MessageID == 11 && msg.Text == next.Text
+		})).Return(question, nil).Once(),
+		// После преобразования группы в супергруппу опрос продолжается
+		mockBot.On("Send", mock.MatchedBy(func(msg tgbotapi.EditMessageTextConfig) bool {
+			return msg.ChatID == supergroupID && msg.MessageID == 11 && msg.Text == first.Text
+		})).Return(question, nil).Once(),
+	)
+
+	HandleMessage(mockBot, &tgbotapi.Message{
+		MessageID: 10,
+		From:      alice,
+		Chat:      group,
+		Text:      "/start",
+		Entities:  []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 6}},
+	})
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: "bob", From: bob, Message: &question, Data: first.Options[0].Data})
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: "alice", From: alice, Message: &question, Data: first.Options[0].Data})
+
+	HandleMessage(mockBot, &tgbotapi.Message{MessageID: 12, Chat: group, MigrateToChatID: supergroupID})
+	HandleMessage(mockBot, &tgbotapi.Message{MessageID: 1, Chat: supergroup, MigrateFromChatID: groupID})
+	migrated := tgbotapi.Message{MessageID: 11, Chat: supergroup}
+	HandleCallbackQuery(mockBot, &tgbotapi.CallbackQuery{ID: "alice", From: alice, Message: &migrated, Data: service.CallbackBack})
+
+	mockBot.AssertExpectations(t)
+	assert.Nil(t, service.GetInstance().GetCurrentQuestion(service.SessionKey{
+		ChatUser: service.ChatUser{ChatID: supergroupID, UserID: bob.ID}, MessageID: 11,
+	}), "У второго участника нет сессии")
+	service.GetInstance().Reset(service.SessionKey{ChatUser: service.ChatUser{ChatID: supergroupID, UserID: alice.ID}, MessageID: 11})
+}
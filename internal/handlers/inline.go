@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/helper"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/search"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	maxInlineResults = 20 // Количество исследований в ответе на inline-запрос (Telegram допускает до 50)
+	inlineCacheTime  = 60 // Время кэширования ответа на стороне Telegram в секундах
+)
+
+// HandleInlineQuery Обработка inline-запроса (@bot HER2): карточки найденных исследований,
+// которые можно отправить в любой чат
+func HandleInlineQuery(bot BotInterface, inlineQuery *tgbotapi.InlineQuery) {
+	var (
+		lang    = i18n.DefaultLanguage
+		now     = time.Now()
+		trials  []*service.Trial
+		results []interface{}
+	)
+
+	if inlineQuery.From != nil {
+		if chosen := service.GetInstance().GetLanguage(inlineQuery.From.ID); chosen != "" {
+			lang = chosen
+		} else if detected := i18n.Normalize(inlineQuery.From.LanguageCode); detected != "" {
+			lang = detected
+		}
+	}
+
+	survey := service.CurrentSurvey()
+	if query := strings.TrimSpace(inlineQuery.Query); query != "" {
+		for _, result := range search.Search(survey.Trials, query, maxInlineResults) {
+			trials = append(trials, result.Trial)
+		}
+	} else {
+		// Без запроса предлагаем исследования с открытым набором
+		for i := range survey.Trials {
+			if survey.Trials[i].IsRecruiting(now) && len(trials) < maxInlineResults {
+				trials = append(trials, &survey.Trials[i])
+			}
+		}
+	}
+
+	for _, trial := range trials {
+		trial = trial.In(lang)
+
+		article := tgbotapi.NewInlineQueryResultArticleMarkdownV2(trial.Code, trial.Code, trialShareText(trial, lang))
+		article.Description = shortText(trial.Title)
+		results = append(results, article)
+	}
+
+	inlineConfig := tgbotapi.InlineConfig{
+		InlineQueryID: inlineQuery.ID,
+		Results:       results,
+		CacheTime:     inlineCacheTime,
+		IsPersonal:    true,
+	}
+	if _, err := bot.Request(inlineConfig); err != nil {
+		log.Println("Error answering inline query:", err)
+	}
+}
+
+// trialShareText Карточка исследования для отправки в другой чат.
+// Кнопок нет: callback из чужого чата не привязан к сессии опроса, поэтому длинное описание сокращается до первой страницы
+func trialShareText(trial *service.Trial, lang string) string {
+	header := "🔬 *" + helper.EscapeMarkdownV2(trial.Code) + "*\n\n"
+	pages := paginate(trialCardBlocks(trial, lang), maxMessageLen-textLen(header)-pageFooterReserve)
+	if len(pages) > 1 {
+		return header + pages[0] + "\n…"
+	}
+	return header + pages[0]
+}
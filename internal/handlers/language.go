@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// language Язык интерфейса пользователя, по умолчанию русский
+func language(userID int64) string {
+	if lang := service.GetInstance().GetLanguage(userID); lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// Запоминание языка из настроек Telegram, если пользователь еще не выбрал язык сам
+func detectLanguage(user *tgbotapi.User) {
+	if user == nil {
+		return
+	}
+
+	surveyService := service.GetInstance()
+	if surveyService.GetLanguage(user.ID) != "" {
+		return
+	}
+	if lang := i18n.Normalize(user.LanguageCode); lang != "" {
+		surveyService.SetLanguage(user.ID, lang)
+	}
+}
+
+// Обработка команды /language: "/language en" сразу меняет язык, без аргумента показывает выбор
+func handleLanguageCommand(bot BotInterface, conv conversation, message *tgbotapi.Message) {
+	if lang := i18n.Normalize(message.CommandArguments()); lang != "" {
+		service.GetInstance().SetLanguage(conv.UserID, lang)
+		sendText(bot, conv, i18n.T(lang, "Язык интерфейса: %s", i18n.Name(lang)))
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, lang := range i18n.Languages {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.Name, service.CallbackLanguagePrefix+lang.Code),
+		))
+	}
+
+	msg := conv.newMessage(i18n.T(conv.lang(), "Выберите язык интерфейса"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := bot.Send(msg); err != nil {
+		log.Println("Error sending message:", err)
+	}
+}
+
+// Обработка кнопки выбора языка. Возвращает false, если callback к выбору языка не относится
+func handleLanguageCallback(bot BotInterface, conv conversation, messageID int, data string) bool {
+	code, ok := strings.CutPrefix(data, service.CallbackLanguagePrefix)
+	if !ok {
+		return false
+	}
+
+	lang := i18n.Normalize(code)
+	if lang == "" {
+		log.Println("unsupported language:", code)
+		return true
+	}
+
+	service.GetInstance().SetLanguage(conv.UserID, lang)
+	editMsg := tgbotapi.NewEditMessageText(conv.ChatID, messageID, i18n.T(lang, "Язык интерфейса: %s", i18n.Name(lang)))
+	if _, err := bot.Send(editMsg); err != nil {
+		log.Println("Error editing message:", err)
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"slices"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Обработка нажатия кнопки вопроса с множественным выбором:
+// вариант переключает отметку, "Готово" переходит по правилу для выбранного набора
+func handleMultiSelect(bot BotInterface, conv conversation, callbackQuery *tgbotapi.CallbackQuery, question *service.Question) {
+	surveyService := service.GetInstance()
+	key := conv.session(callbackQuery.Message.MessageID)
+
+	if callbackQuery.Data == service.CallbackDone {
+		selected := surveyService.GetSelection(key, question.ID)
+		route, err := question.MatchSelectionRoute(selected)
+		if err != nil {
+			log.Println(err)
+			answerCallback(bot, callbackQuery.ID, selectionErrorText(err, conv.lang()))
+			return
+		}
+
+		if err = surveyService.SaveAnswer(key, question.ID, selected...); err != nil {
+			log.Println(err)
+			return
+		}
+
+		applyOption(bot, conv, key, question, &route.Option)
+		answerCallback(bot, callbackQuery.ID, "")
+		return
+	}
+
+	for _, option := range question.Options {
+		if !option.Matches(callbackQuery.Data) {
+			continue
+		}
+
+		if err := surveyService.ToggleSelection(key, question.ID, option.Data); err != nil {
+			log.Println(err)
+			break
+		}
+		editQuestion(bot, conv, key, question)
+		break
+	}
+
+	answerCallback(bot, callbackQuery.ID, "")
+}
+
+// Кнопки вариантов вопроса с множественным выбором с отметками и кнопка "Готово"
+func multiSelectRows(question *service.Question, key service.SessionKey, lang string) (rows [][]tgbotapi.InlineKeyboardButton) {
+	selected := service.GetInstance().GetSelection(key, question.ID)
+
+	for _, option := range question.Options {
+		text := option.TextIn(lang)
+		if slices.Contains(selected, option.Data) {
+			text = "✅ " + text
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(text, option.Data),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "Готово"), service.CallbackDone),
+	))
+	return
+}
+
+// selectionErrorText Текст всплывающего уведомления, если для выбранного набора нет правила перехода
+func selectionErrorText(err error, lang string) string {
+	if errors.Is(err, service.ErrNoRouteMatch) {
+		return i18n.T(lang, "Для выбранных вариантов нет продолжения, измените выбор")
+	}
+	return i18n.T(lang, "Не удалось обработать выбор")
+}
+
+// Ответ на нажатие кнопки, чтобы клиент перестал показывать индикатор загрузки
+func answerCallback(bot BotInterface, callbackID, text string) {
+	if _, err := bot.Request(tgbotapi.NewCallback(callbackID, text)); err != nil {
+		log.Println(err)
+	}
+}
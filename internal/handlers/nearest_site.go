@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"telegram-bot/internal/helper"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxNearestSites Число ближайших центров в ответе на присланное местоположение
+const maxNearestSites = 3
+
+// Запрос местоположения для поиска ближайшего центра исследования.
+// Кнопка отправки местоположения работает только в личном чате, в группе геопозицию присылают ответом через меню вложений
+func requestLocation(bot BotInterface, conv conversation, code string) {
+	lang := conv.lang()
+
+	trial := service.CurrentSurvey().GetTrial(code)
+	if trial == nil {
+		log.Println("trial not found in registry:", code)
+		return
+	}
+	service.GetInstance().SetLocationRequest(conv.ChatUser, code)
+
+	text := i18n.T(lang, "Отправьте местоположение, чтобы найти ближайшие центры исследования %s", trial.Code)
+	if conv.group {
+		sendText(bot, conv, text+"\n"+i18n.T(lang, "Ответьте на это сообщение геопозицией через меню вложений 📎"))
+		return
+	}
+
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonLocation(i18n.T(lang, "📍 Отправить местоположение"))),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, "Отмена"))),
+	)
+	keyboard.OneTimeKeyboard = true
+
+	msg := conv.newMessage(text)
+	msg.ReplyMarkup = keyboard
+	if _, err := bot.Send(msg); err != nil {
+		log.Println("Error sending location request:", err)
+	}
+}
+
+// Ответ на присланное местоположение: ближайшие центры исследования, для которого оно запрошено
+func handleLocation(bot BotInterface, conv conversation, message *tgbotapi.Message) {
+	var (
+		lang          = conv.lang()
+		surveyService = service.GetInstance()
+	)
+
+	code := surveyService.GetLocationRequest(conv.ChatUser)
+	trial := service.CurrentSurvey().GetTrial(code)
+	if code == "" || trial == nil {
+		sendLocationReply(bot, conv, helper.EscapeMarkdownV2(i18n.T(lang, "Откройте карточку исследования и нажмите «📍 Ближайший центр»")))
+		return
+	}
+	surveyService.SetLocationRequest(conv.ChatUser, "")
+
+	nearest := trial.NearestSites(message.Location.Latitude, message.Location.Longitude, maxNearestSites)
+	if len(nearest) == 0 {
+		sendLocationReply(bot, conv, helper.EscapeMarkdownV2(i18n.T(lang, "Для центров исследования %s не указаны координаты", trial.Code)))
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📍 *" + helper.EscapeMarkdownV2(i18n.T(lang, "Ближайшие центры исследования %s", trial.Code)) + ":*\n")
+	for i, item := range nearest {
+		lines := append(
+			[]string{fmt.Sprintf("%d. %s — %s", i+1, siteName(item.Site), formatDistance(item.Distance, lang))},
+			siteDetails(item.Site, lang)...,
+		)
+		builder.WriteString("\n" + helper.EscapeMarkdownV2(strings.Join(lines, "\n")) + "\n")
+	}
+	sendLocationReply(bot, conv, builder.String())
+}
+
+// Отмена запроса местоположения кнопкой "Отмена"
+func handleLocationCancel(bot BotInterface, conv conversation, message *tgbotapi.Message) bool {
+	lang := conv.lang()
+
+	surveyService := service.GetInstance()
+	if surveyService.GetLocationRequest(conv.ChatUser) == "" || message.Text != i18n.T(lang, "Отмена") {
+		return false
+	}
+	surveyService.SetLocationRequest(conv.ChatUser, "")
+
+	sendLocationReply(bot, conv, helper.EscapeMarkdownV2(i18n.T(lang, "Поиск ближайшего центра отменен")))
+	return true
+}
+
+// Отправка ответа в разметке MarkdownV2 с удалением клавиатуры запроса местоположения.
+// В группе клавиатура не показывается, и удалять ее не нужно
+func sendLocationReply(bot BotInterface, conv conversation, text string) {
+	msg := conv.newMessage(text)
+	msg.ParseMode = "MarkdownV2"
+	if !conv.group {
+		msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(false)
+	}
+
+	if _, err := bot.Send(msg); err != nil {
+		log.Println("Error sending nearest sites:", err)
+	}
+}
+
+// formatDistance Расстояние, округленное до километра
+func formatDistance(km float64, lang string) string {
+	return i18n.T(lang, "%d км", int(math.Max(1, math.Round(km))))
+}
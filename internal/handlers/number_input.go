@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Обработка ответа на вопрос с вводом числа. Ответ относится к сессии сообщения, на которое он дан,
+// а без ответа на сообщение — к последнему сообщению опроса участника.
+// В группе числом считается только ответ на последнее сообщение опроса участника:
+// обычная переписка в группе не разбирается и не вызывает повторного вопроса
+func handleNumberAnswer(bot BotInterface, conv conversation, message *tgbotapi.Message) {
+	lang := conv.lang()
+	surveyService := service.GetInstance()
+
+	key := conv.session(surveyService.GetLastMessageID(conv.ChatUser))
+	switch {
+	case conv.group:
+		if message.ReplyToMessage == nil || message.ReplyToMessage.MessageID != key.MessageID {
+			return
+		}
+	case message.ReplyToMessage != nil:
+		key = conv.session(message.ReplyToMessage.MessageID)
+	}
+
+	question := surveyService.GetCurrentQuestion(key)
+	if question == nil || !question.IsNumber() {
+		return
+	}
+
+	value, err := question.ParseNumber(message.Text)
+	if err != nil {
+		sendQuestionText(bot, conv, key, question, "⚠️ "+numberErrorText(err, lang)+"\n\n"+questionText(question, lang))
+		return
+	}
+
+	route, err := question.MatchRoute(value)
+	if err != nil {
+		sendQuestionText(bot, conv, key, question, "⚠️ "+numberErrorText(err, lang)+"\n\n"+questionText(question, lang))
+		return
+	}
+
+	if err = surveyService.SaveAnswer(key, question.ID, service.FormatAnswer(value)); err != nil {
+		log.Println(err)
+		return
+	}
+
+	// Пользователь ответил сообщением, поэтому следующий шаг отправляем новым сообщением
+	surveyService.MoveSession(key, conv.session(0))
+	applyOption(bot, conv, conv.session(0), question, &route.Option)
+}
+
+// questionText Текст вопроса с подсказкой по вводу для вопросов с числом
+func questionText(question *service.Question, lang string) string {
+	if !question.IsNumber() {
+		return question.TextIn(lang)
+	}
+	return question.TextIn(lang) + "\n\n" + numberHint(question.Input, lang)
+}
+
+// numberHint Подсказка о формате ввода числа
+func numberHint(input *service.NumberInput, lang string) string {
+	if input == nil {
+		input = &service.NumberInput{}
+	}
+
+	hint := i18n.T(lang, "Введите число")
+	if input.Integer {
+		hint = i18n.T(lang, "Введите целое число")
+	}
+	if input.Min != nil {
+		hint += " " + i18n.T(lang, "от %s", formatNumber(*input.Min))
+	}
+	if input.Max != nil {
+		hint += " " + i18n.T(lang, "до %s", formatNumber(*input.Max))
+	}
+	if input.Unit != "" {
+		hint += " (" + input.Unit + ")"
+	}
+	return hint
+}
+
+// numberErrorText Описание ошибки ввода числа для пользователя
+func numberErrorText(err error, lang string) string {
+	switch {
+	case errors.Is(err, service.ErrNotInteger):
+		return i18n.T(lang, "Нужно ввести целое число.")
+	case errors.Is(err, service.ErrOutOfRange):
+		return i18n.T(lang, "Значение вне допустимого диапазона.")
+	case errors.Is(err, service.ErrNoRouteMatch):
+		return i18n.T(lang, "Для этого значения нет продолжения опроса.")
+	default:
+		return i18n.T(lang, "Не удалось распознать число.")
+	}
+}
+
+func formatNumber(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', -1, 64), ".", ",", 1)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxMessageLen Ограничение Telegram на длину текста сообщения в единицах UTF-16
+const maxMessageLen = 4096
+
+// pageFooterReserve Запас длины под строку с номером страницы
+const pageFooterReserve = 64
+
+// textLen Длина текста в единицах UTF-16, в которых Telegram считает ограничение.
+// Считается текст с разметкой, поэтому оценка с запасом
+func textLen(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+// paginate Раскладывает блоки текста в разметке MarkdownV2 по страницам не длиннее limit.
+// Блок переносится на следующую страницу целиком, слишком длинный блок делится по границе слова
+// вне сущностей разметки. Пустые строки в начале и конце страницы убираются
+func paginate(blocks []string, limit int) (pages []string) {
+	var page strings.Builder
+
+	flush := func() {
+		if text := strings.Trim(page.String(), "\n"); text != "" {
+			pages = append(pages, text)
+		}
+		page.Reset()
+	}
+
+	for _, block := range blocks {
+		for _, part := range splitBlock(block, limit) {
+			if textLen(page.String())+textLen(part) > limit {
+				flush()
+			}
+			page.WriteString(part)
+		}
+	}
+	flush()
+
+	if len(pages) == 0 {
+		pages = []string{""}
+	}
+	return
+}
+
+// splitBlock Делит блок длиннее limit на части. Место разреза выбирается после перевода строки
+// или перед пробелом, не внутри экранирования и не внутри жирного, курсива и других сущностей.
+// Если сущность длиннее части, она закрывается в конце части и открывается заново в следующей
+func splitBlock(block string, limit int) (parts []string) {
+	for textLen(block) > limit {
+		cut, open := cutPosition(block, limit)
+		parts = append(parts, block[:cut]+closeEntities(open))
+		block = string(open) + strings.TrimLeft(block[cut:], " ")
+	}
+	return append(parts, block)
+}
+
+// closeEntities Закрывающие символы для открытых сущностей разметки, начиная с вложенной
+func closeEntities(open []rune) string {
+	closing := slices.Clone(open)
+	slices.Reverse(closing)
+	return string(closing)
+}
+
+// cutPosition Позиция в байтах для разреза текста длиннее limit и сущности разметки,
+// открытые в этой позиции. Сущности остаются открытыми, только если разрезать вне их негде
+func cutPosition(text string, limit int) (int, []rune) {
+	var (
+		length     int
+		escaped    bool
+		justOpened bool   // предыдущий символ открыл сущность: разрез оставил бы ее пустой
+		open       []rune // открытые сущности в порядке открытия
+		lastWord   int    // последняя граница слова вне сущностей
+		lastSafe   int    // последняя граница символа вне сущностей
+		inWord     int    // последняя граница слова внутри сущностей, где хватает места их закрыть
+		inWordOpen []rune // сущности, открытые на inWord
+		lastChar   int    // последняя граница символа вне экранирования, где хватает места закрыть сущности
+		lastOpen   []rune // сущности, открытые на lastChar
+	)
+
+	for i, r := range text {
+		// Длина text[:i] уже не больше limit
+		if i > 0 && !escaped {
+			if len(open) == 0 {
+				lastSafe = i
+				if r == ' ' || text[i-1] == '\n' {
+					lastWord = i
+				}
+			}
+			if !justOpened && length+len(open) <= limit {
+				lastChar = i
+				lastOpen = slices.Clone(open)
+				if r == ' ' || text[i-1] == '\n' {
+					inWord = i
+					inWordOpen = lastOpen
+				}
+			}
+		}
+
+		length += len(utf16.Encode([]rune{r}))
+		if length > limit {
+			break
+		}
+
+		justOpened = false
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune("*_~`", r):
+			if index := slices.Index(open, r); index >= 0 {
+				open = slices.Delete(open, index, index+1)
+			} else {
+				open = append(open, r)
+				justOpened = true
+			}
+		}
+	}
+
+	switch {
+	case lastWord > 0:
+		return lastWord, nil
+	case lastSafe > 0:
+		return lastSafe, nil
+	case inWord > 0:
+		return inWord, inWordOpen
+	case lastChar > 0:
+		return lastChar, lastOpen
+	}
+	_, size := utf8.DecodeRuneInString(text)
+	return size, nil
+}
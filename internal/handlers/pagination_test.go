@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	blocks := []string{"\n*Заголовок:*\n• первый\n", "• второй\n", "• третий\n"}
+
+	assert.Equal(t, []string{"*Заголовок:*\n• первый\n• второй\n• третий"}, paginate(blocks, 100), "Все помещается на одну страницу")
+
+	pages := paginate(blocks, 35)
+	assert.Equal(t, []string{"*Заголовок:*\n• первый\n• второй", "• третий"}, pages, "Блок переносится целиком")
+
+	assert.Equal(t, []string{""}, paginate(nil, 10))
+}
+
+func TestSplitBlock(t *testing.T) {
+	parts := splitBlock("*Жирный заголовок* слово слово слово", 25)
+	assert.Equal(t, []string{"*Жирный заголовок* слово", "слово слово"}, parts)
+
+	parts = splitBlock(`• доза 1\.5 мг\/кг`, 9)
+	for _, part := range parts {
+		assert.LessOrEqual(t, textLen(part), 9)
+		assert.False(t, strings.HasSuffix(part, `\`), "Экранирование не отрывается от символа: %q", part)
+	}
+	assert.Equal(t, `• доза 1\.5 мг\/кг`, strings.Join(parts, " "))
+
+	bold := "*" + strings.Repeat("а", 10) + "*"
+	parts = splitBlock("*"+strings.Repeat("а", 20)+"* хвост", 12)
+	assert.Equal(t, []string{bold, bold, "хвост"}, parts, "Сущность длиннее страницы закрывается и открывается заново")
+
+	parts = splitBlock("_курсив *жирный* внутри конец_", 10)
+	assert.Equal(t, []string{"_курсив_", "_*жирный*_", "_внутри_", "_конец_"}, parts, "Вложенные сущности закрываются по границе слова")
+
+	assert.Equal(t, 2, textLen("😀"), "Эмодзи занимает две единицы UTF-16")
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/helper"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/search"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxSearchResults Количество исследований в ответе на поиск
+const maxSearchResults = 5
+
+// Обработка команды /search <текст>: лучшие совпадения кнопками, открывающими карточку исследования
+func handleSearchCommand(bot BotInterface, conv conversation, message *tgbotapi.Message) {
+	var (
+		lang  = conv.lang()
+		query = strings.TrimSpace(message.CommandArguments())
+	)
+
+	if query == "" {
+		sendText(bot, conv, i18n.T(lang, "Введите запрос после команды, например: /search HER2"))
+		return
+	}
+
+	results := search.Search(service.CurrentSurvey().Trials, query, maxSearchResults)
+	if len(results) == 0 {
+		sendText(bot, conv, i18n.T(lang, "По запросу «%s» ничего не найдено", query))
+		return
+	}
+
+	var (
+		builder strings.Builder
+		rows    [][]tgbotapi.InlineKeyboardButton
+		now     = time.Now()
+	)
+
+	builder.WriteString("🔍 *" + helper.EscapeMarkdownV2(i18n.T(lang, "Найдено по запросу «%s»", query)) + ":*\n\n")
+	for _, result := range results {
+		trial := result.Trial.In(lang)
+
+		line := "— «" + shortText(trial.Title) + "»"
+		if !trial.IsRecruiting(now) {
+			line += " (" + trial.LocalizedStatusText(lang, now) + ")"
+		}
+		builder.WriteString("• *" + helper.EscapeMarkdownV2(trial.Code) + "* " + helper.EscapeMarkdownV2(line) + "\n")
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(trial.Code, service.CallbackTrialPrefix+trial.Code),
+		))
+	}
+	builder.WriteString("\n" + helper.EscapeMarkdownV2(i18n.T(lang, "Выберите исследование, чтобы открыть описание.")))
+
+	msg := conv.newMessage(builder.String())
+	msg.ParseMode = "MarkdownV2"
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	if _, err := bot.Send(msg); err != nil {
+		log.Println("Error sending message:", err)
+	}
+}
+
+// Отправка простого текстового сообщения
+func sendText(bot BotInterface, conv conversation, text string) {
+	if _, err := bot.Send(conv.newMessage(text)); err != nil {
+		log.Println("Error sending message:", err)
+	}
+}
@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"telegram-bot/internal/helper"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxShortTextLen Длина сокращенного текста в списках
+const maxShortTextLen = 120
+
+// maxCoordinatorButtons Число кнопок чата с координаторами под карточкой исследования
+const maxCoordinatorButtons = 5
+
+// Повторный показ списка исследований или страницы карточки исследования из списка
+func showResults(bot BotInterface, conv conversation, messageID int, payload string, page int) {
+	survey := service.CurrentSurvey()
+
+	option, code := service.ParseResultsCallbackData(survey, payload)
+	if option == nil {
+		log.Println("terminal option not found:", payload)
+		return
+	}
+
+	if code == "" {
+		sendTrialList(bot, messageID, conv, survey, option)
+		return
+	}
+
+	trial := survey.GetTrial(code)
+	if trial == nil {
+		log.Println("trial not found in registry:", code)
+		return
+	}
+	card := trialCard{
+		cardData: service.ResultsCallbackData(option.Data, code),
+		backData: service.ResultsCallbackData(option.Data, ""),
+		page:     page,
+	}
+	sendTrialCard(bot, messageID, conv, trial, card)
+}
+
+// Показ страницы карточки исследования по коду
+func showTrial(bot BotInterface, conv conversation, messageID int, code string, page int) {
+	trial := service.CurrentSurvey().GetTrial(code)
+	if trial == nil {
+		log.Println("trial not found in registry:", code)
+		return
+	}
+	sendTrialCard(bot, messageID, conv, trial, trialCard{cardData: service.CallbackTrialPrefix + code, page: page})
+}
+
+// Переход на другую страницу длинной карточки исследования
+func showTrialPage(bot BotInterface, conv conversation, messageID int, payload string) {
+	cardData, page, ok := service.ParsePageCallbackData(payload)
+	if !ok {
+		log.Println("invalid page callback:", payload)
+		return
+	}
+
+	if results, ok := strings.CutPrefix(cardData, service.CallbackResultsPrefix); ok {
+		showResults(bot, conv, messageID, results, page)
+		return
+	}
+	if code, ok := strings.CutPrefix(cardData, service.CallbackTrialPrefix); ok {
+		showTrial(bot, conv, messageID, code, page)
+		return
+	}
+	log.Println("unknown card in page callback:", payload)
+}
+
+// trialCard Откуда открыта карточка исследования и какую страницу показать
+type trialCard struct {
+	cardData string // callback data, которым открыта карточка, для кнопок перехода по страницам
+	backData string // callback кнопки возврата к списку (если есть)
+	page     int
+}
+
+// Отправка страницы карточки исследования. Описание, которое не помещается в одно сообщение,
+// делится на страницы с кнопками "◀" и "▶"
+func sendTrialCard(bot BotInterface, messageID int, conv conversation, trial *service.Trial, card trialCard) {
+	lang := conv.lang()
+
+	header := "✅ *" + helper.EscapeMarkdownV2(i18n.T(lang, "Подходящее исследование")) + ":* " + helper.EscapeMarkdownV2(trial.Code) + "\n\n"
+	pages := paginate(trialCardBlocks(trial.In(lang), lang), maxMessageLen-textLen(header)-pageFooterReserve)
+	page := min(card.page, len(pages)-1)
+
+	messageText := header + pages[page]
+	if len(pages) > 1 {
+		messageText += "\n\n_" + helper.EscapeMarkdownV2(i18n.T(lang, "Страница %d из %d", page+1, len(pages))) + "_"
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(pages) > 1 {
+		rows = append(rows, pageRow(trial, card.cardData, page, len(pages)))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "☑️ Проверить критерии"), service.CallbackCheckPrefix+trial.Code),
+	))
+	if len(trial.Documents) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📄 Синопсис"), service.CallbackDocPrefix+trial.Code),
+		))
+	}
+	if trial.HasSiteLocations() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "📍 Ближайший центр"), service.CallbackNearPrefix+trial.Code),
+		))
+	}
+	rows = append(rows, coordinatorRows(trial, lang)...)
+	if card.backData != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "◀ К списку исследований"), card.backData),
+		))
+	}
+	rows = append(rows, restartKeyboardRow(lang))
+
+	editResultMessage(bot, messageID, conv, messageText, tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// pageRow Кнопки перехода на предыдущую и следующую страницу карточки
+func pageRow(trial *service.Trial, cardData string, page, total int) []tgbotapi.InlineKeyboardButton {
+	callbackData := func(page int) string {
+		data := service.PageCallbackData(cardData, page)
+		// Карточка из длинного списка открывается по коду без кнопки возврата к списку
+		if len(data) > service.MaxCallbackDataLen {
+			data = service.PageCallbackData(service.CallbackTrialPrefix+trial.Code, page)
+		}
+		return data
+	}
+
+	var row []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀", callbackData(page-1)))
+	}
+	if page < total-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("▶", callbackData(page+1)))
+	}
+	return row
+}
+
+// Отправка краткого списка исследований с открытым набором с кнопкой на каждое
+func sendTrialList(bot BotInterface, messageID int, conv conversation, survey *service.Survey, option *service.Option) {
+	var (
+		builder strings.Builder
+
+		list    strings.Builder
+		notices []string
+		rows    [][]tgbotapi.InlineKeyboardButton
+		now     = time.Now()
+		lang    = conv.lang()
+	)
+
+	for _, code := range option.Trials {
+		trial := survey.GetTrial(code)
+		if trial == nil {
+			log.Println("trial not found in registry:", code)
+			continue
+		}
+		trial = trial.In(lang)
+
+		// Не рекламируем исследования, в которые сейчас не идет набор
+		if !trial.IsRecruiting(now) {
+			notices = append(notices, "⚠️ "+trial.Code+": "+trial.LocalizedStatusText(lang, now))
+			continue
+		}
+
+		list.WriteString("• *" + helper.EscapeMarkdownV2(trial.Code) + "* ")
+		list.WriteString(helper.EscapeMarkdownV2("— «"+shortText(trial.Title)+"»") + "\n")
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(trial.Code, service.ResultsCallbackData(option.Data, code)),
+		))
+	}
+
+	if len(rows) > 0 {
+		builder.WriteString(fmt.Sprintf("✅ *%s:* %d\n\n", helper.EscapeMarkdownV2(i18n.T(lang, "Подходящие исследования")), len(rows)))
+		builder.WriteString(list.String())
+	} else {
+		builder.WriteString("❌ *" + helper.EscapeMarkdownV2(i18n.T(lang, "Нет исследований с открытым набором")) + "*\n")
+	}
+
+	if len(notices) > 0 {
+		builder.WriteString("\n" + helper.EscapeMarkdownV2(strings.Join(notices, "\n")) + "\n")
+	}
+
+	if len(rows) > 0 {
+		builder.WriteString("\n" + helper.EscapeMarkdownV2(i18n.T(lang, "Выберите исследование, чтобы открыть описание.")))
+	} else {
+		builder.WriteString("\n" + helper.EscapeMarkdownV2(i18n.T(lang, "Для выбранного варианта сейчас нет открытых исследований.")))
+	}
+	rows = append(rows, restartKeyboardRow(lang))
+
+	editResultMessage(bot, messageID, conv, builder.String(), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// Редактирование сообщения с результатом в разметке MarkdownV2.
+// При messageID == 0 отправляется новое сообщение, которое становится последним для участника чата
+func editResultMessage(
+	bot BotInterface,
+	messageID int,
+	conv conversation,
+	text string,
+	keyboard tgbotapi.InlineKeyboardMarkup,
+) {
+	if messageID == 0 {
+		msg := conv.newMessage(text)
+		msg.ParseMode = "MarkdownV2"
+		msg.ReplyMarkup = keyboard
+
+		sentMsg, err := bot.Send(msg)
+		if err != nil {
+			log.Println("Error sending results:", err)
+			return
+		}
+		service.GetInstance().SetLastMessageID(conv.ChatUser, sentMsg.MessageID)
+		return
+	}
+
+	editMsg := tgbotapi.NewEditMessageTextAndMarkup(conv.ChatID, messageID, text, keyboard)
+	editMsg.ParseMode = "MarkdownV2"
+
+	if _, err := bot.Send(editMsg); err != nil {
+		log.Println("Error sending results:", err)
+	}
+}
+
+// Кнопка "Начать заново"
+func restartKeyboardRow(lang string) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "🔄 Начать заново"), service.CallbackStart),
+	)
+}
+
+// shortText Сокращает длинный текст (название, критерий) по границе слова
+func shortText(text string) string {
+	if utf8.RuneCountInString(text) <= maxShortTextLen {
+		return text
+	}
+
+	runes := []rune(text)[:maxShortTextLen]
+	if cut := strings.LastIndex(string(runes), " "); cut > 0 {
+		return strings.TrimRight(string(runes)[:cut], ",;:") + "…"
+	}
+	return string(runes) + "…"
+}
+
+// trialCardBlocks Описание исследования в разметке MarkdownV2, разбитое на блоки,
+// между которыми можно перейти на следующую страницу
+func trialCardBlocks(trial *service.Trial, lang string) (blocks []string) {
+	var details []string
+	if trial.Sponsor != "" {
+		details = append(details, i18n.T(lang, "Спонсор: %s", trial.Sponsor))
+	}
+	if trial.Phase != "" {
+		details = append(details, i18n.T(lang, "Фаза: %s", trial.Phase))
+	}
+	details = append(details, i18n.T(lang, "Статус: %s", trial.LocalizedStatusText(lang, time.Now())))
+	if period := trial.EnrollmentPeriod(lang); period != "" {
+		details = append(details, i18n.T(lang, "Набор: %s", period))
+	}
+	if trial.Contacts != "" {
+		details = append(details, i18n.T(lang, "Контакты: %s", trial.Contacts))
+	}
+	blocks = append(blocks,
+		helper.EscapeMarkdownV2("«"+trial.Title+"»")+"\n",
+		"\n"+helper.EscapeMarkdownV2(strings.Join(details, "\n"))+"\n",
+	)
+
+	blocks = append(blocks, bulletBlocks(i18n.T(lang, "Критерии включения"), trial.Inclusion)...)
+	blocks = append(blocks, bulletBlocks(i18n.T(lang, "Критерии невключения"), trial.Exclusion)...)
+
+	var sites []string
+	for _, site := range trial.Sites {
+		lines := append([]string{siteName(&site)}, siteDetails(&site, lang)...)
+		sites = append(sites, strings.Join(lines, "\n"))
+	}
+	blocks = append(blocks, bulletBlocks(i18n.T(lang, "Исследовательские центры"), sites)...)
+
+	return
+}
+
+// siteName Название центра с городом
+func siteName(site *service.Site) string {
+	return strings.TrimSuffix(site.Name+", "+site.City, ", ")
+}
+
+// siteDetails Адрес, главный исследователь и контакты координатора центра с отступом под пунктом списка
+func siteDetails(site *service.Site, lang string) (lines []string) {
+	if site.Address != "" {
+		lines = append(lines, "   "+site.Address)
+	}
+	if site.Investigator != "" {
+		lines = append(lines, "   "+i18n.T(lang, "Главный исследователь: %s", site.Investigator))
+	}
+
+	var contacts []string
+	if site.Phone != "" {
+		contacts = append(contacts, site.Phone)
+	}
+	if site.ChatURL() != "" {
+		contacts = append(contacts, "@"+site.TelegramUsername())
+	}
+	if len(contacts) > 0 {
+		lines = append(lines, "   "+i18n.T(lang, "Координатор: %s", strings.Join(contacts, ", ")))
+	}
+	return
+}
+
+// coordinatorRows Кнопки личного чата с координаторами центров. Повторяющиеся координаторы пропускаются
+func coordinatorRows(trial *service.Trial, lang string) (rows [][]tgbotapi.InlineKeyboardButton) {
+	var (
+		seen  = map[string]bool{}
+		sites []service.Site
+	)
+	for _, site := range trial.Sites {
+		if url := site.ChatURL(); url != "" && !seen[strings.ToLower(url)] {
+			seen[strings.ToLower(url)] = true
+			sites = append(sites, site)
+		}
+	}
+
+	for i, site := range sites {
+		if i == maxCoordinatorButtons {
+			break
+		}
+		text := i18n.T(lang, "💬 Написать координатору")
+		if len(sites) > 1 {
+			text = i18n.T(lang, "💬 Координатор: %s", strings.TrimSuffix(site.City+" — "+site.Name, " — "))
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(text, site.ChatURL())))
+	}
+	return
+}
+
+// writeBulletList Добавляет список с жирным заголовком
+func writeBulletList(builder *strings.Builder, title string, items []string) {
+	builder.WriteString(strings.Join(bulletBlocks(title, items), ""))
+}
+
+// bulletBlocks Список с жирным заголовком: блок на каждый пункт, заголовок не отрывается от первого пункта
+func bulletBlocks(title string, items []string) (blocks []string) {
+	for i, item := range items {
+		block := helper.EscapeMarkdownV2("• "+item) + "\n"
+		if i == 0 {
+			block = "\n*" + helper.EscapeMarkdownV2(title) + ":*\n" + block
+		}
+		blocks = append(blocks, block)
+	}
+	return
+}
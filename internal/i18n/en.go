@@ -0,0 +1,95 @@
+package i18n
+
+// english Переводы строк интерфейса на английский
+var english = map[string]string{
+	// Опрос
+	"Назад":               "Back",
+	"Готово":              "Done",
+	"Введите число":       "Enter a number",
+	"Введите целое число": "Enter a whole number",
+	"от %s":               "from %s",
+	"до %s":               "to %s",
+	"Нужно ввести целое число.":                                        "A whole number is required.",
+	"Значение вне допустимого диапазона.":                              "The value is out of the allowed range.",
+	"Для этого значения нет продолжения опроса.":                       "The survey has no branch for this value.",
+	"Не удалось распознать число.":                                     "Could not recognize the number.",
+	"Для выбранных вариантов нет продолжения, измените выбор":          "The survey has no branch for this selection, please change it",
+	"Не удалось обработать выбор":                                      "Could not process the selection",
+	"Сессия устарела, начните заново":                                  "The session has expired, please start over",
+	"Это опрос другого участника. Отправьте /start, чтобы начать свой": "This is another member's survey. Send /start to begin your own",
+
+	// Результаты
+	"Подходящее исследование":                                   "Matching study",
+	"Подходящие исследования":                                   "Matching studies",
+	"☑️ Проверить критерии":                                     "☑️ Check criteria",
+	"◀ К списку исследований":                                   "◀ Back to the list of studies",
+	"🔄 Начать заново":                                           "🔄 Start over",
+	"Нет исследований с открытым набором":                       "No studies with open enrollment",
+	"Выберите исследование, чтобы открыть описание.":            "Select a study to open its description.",
+	"Для выбранного варианта сейчас нет открытых исследований.": "There are currently no open studies for the selected answer.",
+	"Спонсор: %s":               "Sponsor: %s",
+	"Контакты: %s":              "Contacts: %s",
+	"Главный исследователь: %s": "Principal investigator: %s",
+	"Координатор: %s":           "Coordinator: %s",
+	"💬 Написать координатору":   "💬 Message the coordinator",
+	"💬 Координатор: %s":         "💬 Coordinator: %s",
+	"Фаза: %s":                  "Phase: %s",
+	"Статус: %s":                "Status: %s",
+	"Набор: %s":                 "Enrollment: %s",
+	"с %s":                      "from %s",
+	"по %s":                     "until %s",
+	"Критерии включения":        "Inclusion criteria",
+	"Критерии невключения":      "Exclusion criteria",
+	"Исследовательские центры":  "Study sites",
+	"📄 Синопсис":                "📄 Synopsis",
+	"Синопсис протокола":        "Protocol synopsis",
+	"набор открыт":              "enrollment open",
+	"набор приостановлен":       "enrollment paused",
+	"набор завершен":            "enrollment closed",
+	"набор начнется %s":         "enrollment starts on %s",
+	"Страница %d из %d":         "Page %d of %d",
+
+	// Ближайший центр
+	"📍 Ближайший центр":          "📍 Nearest site",
+	"📍 Отправить местоположение": "📍 Share location",
+	"Отмена": "Cancel",
+	"Отправьте местоположение, чтобы найти ближайшие центры исследования %s": "Share your location to find the nearest sites of study %s",
+	"Ответьте на это сообщение геопозицией через меню вложений 📎":            "Reply to this message with a location from the attachment menu 📎",
+	"Откройте карточку исследования и нажмите «📍 Ближайший центр»":           "Open a study card and tap “📍 Nearest site”",
+	"Для центров исследования %s не указаны координаты":                      "No coordinates are specified for the sites of study %s",
+	"Ближайшие центры исследования %s":                                       "Nearest sites of study %s",
+	"Поиск ближайшего центра отменен":                                        "Nearest site search cancelled",
+	"%d км": "%d km",
+
+	// Проверка критериев
+	"Проверка критериев":              "Criteria check",
+	"Критерий включения %d из %d":     "Inclusion criterion %d of %d",
+	"Критерий невключения %d из %d":   "Exclusion criterion %d of %d",
+	"Пациент соответствует критерию?": "Does the patient meet this criterion?",
+	"Есть ли это у пациента?":         "Does the patient have this?",
+	"✅ Да":         "✅ Yes",
+	"❌ Нет":        "❌ No",
+	"❔ Неизвестно": "❔ Unknown",
+	"Пациент вероятно подходит для исследования":                      "The patient is likely eligible for the study",
+	"Критерии включения выполнены, критерии невключения отсутствуют.": "Inclusion criteria are met, no exclusion criteria apply.",
+	"Пациент не подходит для исследования":                            "The patient is not eligible for the study",
+	"Не выполнены критерии":                                           "Criteria not met",
+	"Нужны дополнительные данные для исследования":                    "More data is needed for the study",
+	"Нет данных по критериям":                                         "No data for criteria",
+	"◀ Изменить ответ":                                                "◀ Change answer",
+	"📋 Описание":                                                      "📋 Description",
+	"(включения) %s":                                                  "(inclusion) %s",
+	"(невключения) %s":                                                "(exclusion) %s",
+
+	// Поиск
+	"Введите запрос после команды, например: /search HER2": "Type a query after the command, for example: /search HER2",
+	"По запросу «%s» ничего не найдено":                    "Nothing found for “%s”",
+	"Найдено по запросу «%s»":                              "Results for “%s”",
+
+	// Служебные сообщения
+	"Выберите язык интерфейса":                 "Choose the interface language",
+	"Язык интерфейса: %s":                      "Interface language: %s",
+	"Опросник обновлен":                        "Survey reloaded",
+	"Ошибка обновления опросника: %v":          "Survey reload failed: %v",
+	", найдено ошибок: %d (см. cmd/treecheck)": ", problems found: %d (see cmd/treecheck)",
+}
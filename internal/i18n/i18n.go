@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLanguage Язык по умолчанию, на нем же написаны исходные тексты
+const DefaultLanguage = "ru"
+
+// Language Поддерживаемый язык интерфейса
+type Language struct {
+	Code string // Код языка как в language_code Telegram
+	Name string // Название языка на нем самом
+}
+
+// Languages Поддерживаемые языки интерфейса
+var Languages = []Language{
+	{Code: "ru", Name: "Русский"},
+	{Code: "en", Name: "English"},
+}
+
+// translations Переводы строк интерфейса по языкам. Ключом служит русский текст
+var translations = map[string]map[string]string{
+	"en": english,
+}
+
+// T возвращает строку интерфейса на языке lang. Если перевода нет, используется русский текст.
+// Аргументы подставляются как в fmt.Sprintf
+func T(lang, key string, args ...any) string {
+	text := key
+	if translated, ok := translations[lang][key]; ok {
+		text = translated
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Normalize приводит код языка (например, language_code "en-US") к поддерживаемому языку.
+// Для неподдерживаемого языка возвращается пустая строка
+func Normalize(code string) string {
+	code, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(code)), "-")
+	for _, language := range Languages {
+		if language.Code == code {
+			return code
+		}
+	}
+	return ""
+}
+
+// Name возвращает название языка по коду
+func Name(code string) string {
+	for _, language := range Languages {
+		if language.Code == code {
+			return language.Name
+		}
+	}
+	return code
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Back", T("en", "Назад"))
+	assert.Equal(t, "Назад", T("ru", "Назад"))
+	assert.Equal(t, "Назад", T("de", "Назад"), "Неподдерживаемый язык")
+	assert.Equal(t, "Нет перевода", T("en", "Нет перевода"), "Строка без перевода")
+	assert.Equal(t, "Inclusion criterion 2 of 5", T("en", "Критерий включения %d из %d", 2, 5))
+	assert.Equal(t, "Критерий включения 2 из 5", T("ru", "Критерий включения %d из %d", 2, 5))
+}
+
+func TestNormalize(t *testing.T) {
+	for code, expected := range map[string]string{
+		"en":    "en",
+		"en-US": "en",
+		" RU ":  "ru",
+		"de":    "",
+		"":      "",
+	} {
+		assert.Equal(t, expected, Normalize(code), code)
+	}
+}
+
+func TestTranslationsKeepPlaceholders(t *testing.T) {
+	for lang, messages := range translations {
+		for key, text := range messages {
+			assert.Equal(t, countVerbs(key), countVerbs(text), "%s: %q", lang, key)
+		}
+	}
+}
+
+// TestEnglishCoversSources Каждая строка, переданная в T литералом в обработчиках и сервисе, переведена на английский
+func TestEnglishCoversSources(t *testing.T) {
+	for _, dir := range []string{"../handlers", "../service"} {
+		keys := sourceKeys(t, dir)
+		assert.NotEmpty(t, keys, dir)
+		for _, key := range keys {
+			_, ok := english[key]
+			assert.True(t, ok, "%s: нет перевода %q", dir, key)
+		}
+	}
+}
+
+// sourceKeys Ключи вызовов i18n.T со строковым литералом в исходниках пакета dir, кроме тестов
+func sourceKeys(t *testing.T, dir string) (keys []string) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "T" {
+				return true
+			}
+			if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			if literal, ok := call.Args[1].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+				key, err := strconv.Unquote(literal.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				keys = append(keys, key)
+			}
+			return true
+		})
+	}
+	return
+}
+
+func countVerbs(text string) (count int) {
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] == '%' {
+			count++
+			i++
+		}
+	}
+	return
+}
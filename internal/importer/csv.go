@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"telegram-bot/internal/service"
+)
+
+// Колонки таблицы координаторов
+const (
+	columnCode      = "code"
+	columnTitle     = "title"
+	columnNosology  = "nosology"
+	columnStatus    = "status"
+	columnInclusion = "inclusion"
+	columnExclusion = "exclusion"
+	columnContacts  = "contacts"
+)
+
+// requiredColumns Колонки, без которых таблицу нельзя импортировать
+var requiredColumns = []string{columnCode, columnTitle, columnNosology, columnStatus, columnInclusion, columnExclusion, columnContacts}
+
+// requiredFields Поля, которые должны быть заполнены в каждой строке
+var requiredFields = map[string]string{
+	columnCode:      "код",
+	columnTitle:     "название",
+	columnNosology:  "нозология",
+	columnStatus:    "статус",
+	columnInclusion: "критерии включения",
+}
+
+// columnAliases Заголовки колонок: английские из шаблона и русские из таблицы координаторов
+var columnAliases = map[string]string{
+	"code":               columnCode,
+	"код":                columnCode,
+	"шифр":               columnCode,
+	"title":              columnTitle,
+	"название":           columnTitle,
+	"nosology":           columnNosology,
+	"нозология":          columnNosology,
+	"status":             columnStatus,
+	"статус":             columnStatus,
+	"inclusion":          columnInclusion,
+	"критерии включения": columnInclusion,
+	"exclusion":          columnExclusion,
+	"критерии невключения": columnExclusion,
+	"критерии исключения":  columnExclusion,
+	"contacts":             columnContacts,
+	"контакты":             columnContacts,
+}
+
+// statusAliases Статусы набора в том виде, в котором их пишут координаторы
+var statusAliases = map[string]service.TrialStatus{
+	"recruiting":          service.StatusRecruiting,
+	"открыт":              service.StatusRecruiting,
+	"набор открыт":        service.StatusRecruiting,
+	"идет набор":          service.StatusRecruiting,
+	"paused":              service.StatusPaused,
+	"приостановлен":       service.StatusPaused,
+	"набор приостановлен": service.StatusPaused,
+	"closed":              service.StatusClosed,
+	"закрыт":              service.StatusClosed,
+	"завершен":            service.StatusClosed,
+	"набор завершен":      service.StatusClosed,
+}
+
+// ErrNoHeader Таблица пустая или в ней нет нужных колонок
+var ErrNoHeader = errors.New("CSV HAS NO VALID HEADER")
+
+// bulletPrefix Маркер списка в начале критерия: "•", "-", "1." или "1)"
+var bulletPrefix = regexp.MustCompile(`^(?:[•\-–*]\s*|\d+[.)]\s+)`)
+
+// ReadCSV читает выгрузку таблицы координаторов. Разделитель (запятая или точка с запятой) определяется
+// по заголовку, критерии в ячейке разделяются переносом строки.
+// Строки с незаполненными полями, неизвестной нозологией или повторным кодом пропускаются и попадают в problems.
+// Если nosologies пуст, нозология не проверяется
+func ReadCSV(r io.Reader, nosologies []string) (trials []service.Trial, problems []service.Problem, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("READ CSV: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM из Excel
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrNoHeader, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		if column, ok := columnAliases[normalize(name)]; ok {
+			columns[column] = i
+		}
+	}
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("%w: NO COLUMN %s", ErrNoHeader, column)
+		}
+	}
+
+	seenCode := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("PARSE CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		cell := func(column string) string {
+			if i := columns[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		code := cell(columnCode)
+		location := fmt.Sprintf("строка %d", line)
+		if code != "" {
+			location += ", " + code
+		}
+		report := func(format string, args ...any) {
+			problems = append(problems, service.Problem{Location: location, Message: fmt.Sprintf(format, args...)})
+		}
+
+		var missing []string
+		for _, column := range requiredColumns {
+			if name, ok := requiredFields[column]; ok && cell(column) == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			report("не заполнено: %s", strings.Join(missing, ", "))
+			continue
+		}
+
+		if first, ok := seenCode[code]; ok {
+			report("код исследования повторяет строку %d", first)
+			continue
+		}
+		seenCode[code] = line
+
+		nosology, ok := matchNosology(cell(columnNosology), nosologies)
+		if !ok {
+			report("неизвестная нозология %q", cell(columnNosology))
+			continue
+		}
+
+		status, ok := statusAliases[normalize(cell(columnStatus))]
+		if !ok {
+			report("неизвестный статус набора %q", cell(columnStatus))
+			continue
+		}
+
+		trials = append(trials, service.Trial{
+			Code:      code,
+			Title:     cell(columnTitle),
+			Nosology:  nosology,
+			Status:    status,
+			Inclusion: splitCriteria(cell(columnInclusion)),
+			Exclusion: splitCriteria(cell(columnExclusion)),
+			Contacts:  cell(columnContacts),
+		})
+	}
+	return trials, problems, nil
+}
+
+// MergeCSV обновляет реестр исследованиями из таблицы координаторов.
+// Колонки таблицы заменяют поля исследования с тем же кодом, остальные поля
+// (спонсор, фаза, центры, сроки набора, переводы) сохраняются. Новые исследования добавляются в конец
+func MergeCSV(registry, imported []service.Trial) []service.Trial {
+	merged := append([]service.Trial{}, registry...)
+
+	for _, trial := range imported {
+		existing := findTrial(merged, trial.Code)
+		if existing == nil {
+			merged = append(merged, trial)
+			continue
+		}
+		existing.Title = trial.Title
+		existing.Nosology = trial.Nosology
+		existing.Status = trial.Status
+		existing.Inclusion = trial.Inclusion
+		existing.Exclusion = trial.Exclusion
+		existing.Contacts = trial.Contacts
+	}
+	return merged
+}
+
+func findTrial(trials []service.Trial, code string) *service.Trial {
+	for i := range trials {
+		if trials[i].Code == code {
+			return &trials[i]
+		}
+	}
+	return nil
+}
+
+// detectDelimiter Excel с русской локалью сохраняет CSV через точку с запятой
+func detectDelimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// matchNosology Сопоставляет нозологию из таблицы с ответами опросника без учета регистра и "ё"
+func matchNosology(value string, nosologies []string) (string, bool) {
+	if len(nosologies) == 0 {
+		return value, true
+	}
+	for _, nosology := range nosologies {
+		if normalize(nosology) == normalize(value) {
+			return nosology, true
+		}
+	}
+	return "", false
+}
+
+// splitCriteria Разбивает ячейку с критериями по строкам и убирает маркеры списка
+func splitCriteria(cell string) (criteria []string) {
+	for _, line := range strings.Split(cell, "\n") {
+		line = strings.TrimSpace(bulletPrefix.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line != "" {
+			criteria = append(criteria, line)
+		}
+	}
+	return
+}
+
+func normalize(text string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.Join(strings.Fields(text), " ")), "ё", "е")
+}
@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"telegram-bot/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const coordinatorsCSV = "\xef\xbb\xbfКод;Название;Нозология;Статус;Критерии включения;Критерии невключения;Контакты\n" +
+	"RB-012;Исследование RB-012;рак легкого;Набор открыт;\"1. Возраст ≥ 18 лет\n2. ECOG 0-1\";• Беременность;Иванова А. А., +7 900 000-00-00\n" +
+	";Без кода;Меланома;открыт;Критерий;;\n" +
+	"RB-012;Повтор;Меланома;открыт;Критерий;;\n" +
+	"GC-001;Рак желудка;Рак желудка;открыт;Критерий;;\n" +
+	"MEL-7;Меланома;Меланома;на паузе;Критерий;;\n" +
+	"MEL-8;Меланома 2;Меланома;Приостановлен;- 1.5 мг/кг;;\n" +
+	";;;;;;\n"
+
+func TestReadCSV(t *testing.T) {
+	trials, problems, err := ReadCSV(strings.NewReader(coordinatorsCSV), []string{"Рак легкого", "Меланома"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, trials, 2) {
+		assert.Equal(t, service.Trial{
+			Code:      "RB-012",
+			Title:     "Исследование RB-012",
+			Nosology:  "Рак легкого",
+			Status:    service.StatusRecruiting,
+			Inclusion: []string{"Возраст ≥ 18 лет", "ECOG 0-1"},
+			Exclusion: []string{"Беременность"},
+			Contacts:  "Иванова А. А., +7 900 000-00-00",
+		}, trials[0])
+		assert.Equal(t, service.StatusPaused, trials[1].Status)
+		assert.Equal(t, []string{"1.5 мг/кг"}, trials[1].Inclusion, "Число в начале критерия не считается маркером")
+	}
+
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+	assert.Equal(t, []string{
+		"[строка 4] не заполнено: код",
+		"[строка 5, RB-012] код исследования повторяет строку 2",
+		`[строка 6, GC-001] неизвестная нозология "Рак желудка"`,
+		`[строка 7, MEL-7] неизвестный статус набора "на паузе"`,
+	}, messages)
+}
+
+func TestReadCSVHeader(t *testing.T) {
+	trials, problems, err := ReadCSV(strings.NewReader(
+		"code,title,nosology,status,inclusion,exclusion,contacts\nA-1,Title,Любая,closed,Criterion,,\n"), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+	if assert.Len(t, trials, 1) {
+		assert.Equal(t, "Любая", trials[0].Nosology, "Без списка нозологий проверка не выполняется")
+		assert.Equal(t, service.StatusClosed, trials[0].Status)
+	}
+
+	_, _, err = ReadCSV(strings.NewReader("code,title\nA-1,Title\n"), nil)
+	assert.ErrorIs(t, err, ErrNoHeader)
+
+	_, _, err = ReadCSV(strings.NewReader(""), nil)
+	assert.ErrorIs(t, err, ErrNoHeader)
+}
+
+func TestMergeCSV(t *testing.T) {
+	registry := []service.Trial{
+		{Code: "RB-012", Title: "Старое", Sponsor: "Спонсор", Sites: []service.Site{{Name: "Центр"}}},
+		{Code: "OTHER", Title: "Не из таблицы"},
+	}
+	imported := []service.Trial{
+		{Code: "RB-012", Title: "Новое", Status: service.StatusClosed, Inclusion: []string{"Критерий"}},
+		{Code: "NEW", Title: "Новое исследование"},
+	}
+
+	merged := MergeCSV(registry, imported)
+	if !assert.Len(t, merged, 3) {
+		return
+	}
+	assert.Equal(t, "Новое", merged[0].Title)
+	assert.Equal(t, service.StatusClosed, merged[0].Status)
+	assert.Equal(t, "Спонсор", merged[0].Sponsor, "Поля вне таблицы сохраняются")
+	assert.Len(t, merged[0].Sites, 1)
+	assert.Equal(t, "OTHER", merged[1].Code)
+	assert.Equal(t, "NEW", merged[2].Code)
+	assert.Equal(t, "Старое", registry[0].Title, "Исходный реестр не меняется")
+}
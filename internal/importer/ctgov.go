@@ -0,0 +1,292 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"telegram-bot/internal/service"
+)
+
+// ErrNoStudies В файле нет ни одного исследования ClinicalTrials.gov
+var ErrNoStudies = errors.New("CLINICALTRIALS.GOV FILE HAS NO STUDIES")
+
+// Study Запись исследования ClinicalTrials.gov API v2 (только используемые поля)
+type Study struct {
+	ProtocolSection struct {
+		IdentificationModule struct {
+			NCTID      string `json:"nctId"`
+			BriefTitle string `json:"briefTitle"`
+		} `json:"identificationModule"`
+		StatusModule struct {
+			OverallStatus string `json:"overallStatus"`
+		} `json:"statusModule"`
+		SponsorCollaboratorsModule struct {
+			LeadSponsor struct {
+				Name string `json:"name"`
+			} `json:"leadSponsor"`
+		} `json:"sponsorCollaboratorsModule"`
+		ConditionsModule struct {
+			Conditions []string `json:"conditions"`
+		} `json:"conditionsModule"`
+		DesignModule struct {
+			Phases []string `json:"phases"`
+		} `json:"designModule"`
+		EligibilityModule struct {
+			EligibilityCriteria string `json:"eligibilityCriteria"`
+		} `json:"eligibilityModule"`
+		ContactsLocationsModule struct {
+			Locations []Location `json:"locations"`
+		} `json:"contactsLocationsModule"`
+	} `json:"protocolSection"`
+}
+
+// Location Исследовательский центр в записи ClinicalTrials.gov
+type Location struct {
+	Facility string `json:"facility"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+	GeoPoint struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"geoPoint"`
+}
+
+// ctgovStatuses Статусы ClinicalTrials.gov, которые соответствуют статусам набора реестра
+var ctgovStatuses = map[string]service.TrialStatus{
+	"RECRUITING":              service.StatusRecruiting,
+	"ENROLLING_BY_INVITATION": service.StatusRecruiting,
+	"NOT_YET_RECRUITING":      service.StatusPaused,
+	"SUSPENDED":               service.StatusPaused,
+	"UNKNOWN":                 service.StatusPaused,
+	"ACTIVE_NOT_RECRUITING":   service.StatusClosed,
+	"COMPLETED":               service.StatusClosed,
+	"TERMINATED":              service.StatusClosed,
+	"WITHDRAWN":               service.StatusClosed,
+}
+
+// ctgovStatus Статус набора реестра для статуса ClinicalTrials.gov.
+// Пустой статус в реестре означает открытый набор, поэтому неизвестный статус считается приостановленным
+func ctgovStatus(status string) service.TrialStatus {
+	if mapped, ok := ctgovStatuses[status]; ok {
+		return mapped
+	}
+	return service.StatusPaused
+}
+
+// ctgovPhases Фазы ClinicalTrials.gov в записи реестра
+var ctgovPhases = map[string]string{
+	"EARLY_PHASE1": "I",
+	"PHASE1":       "I",
+	"PHASE2":       "II",
+	"PHASE3":       "III",
+	"PHASE4":       "IV",
+}
+
+// ReadClinicalTrials читает выгрузку ClinicalTrials.gov API v2: одно исследование (/studies/{nctId})
+// или ответ поиска со списком studies
+func ReadClinicalTrials(r io.Reader) ([]Study, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("READ CLINICALTRIALS.GOV FILE: %w", err)
+	}
+
+	// Ответ поиска содержит studies, запись одного исследования — сразу protocolSection
+	var file struct {
+		Studies []Study `json:"studies"`
+		Study
+	}
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("PARSE CLINICALTRIALS.GOV FILE: %w", err)
+	}
+	if file.Studies == nil {
+		file.Studies = []Study{file.Study}
+	}
+
+	var studies []Study
+	for _, study := range file.Studies {
+		if study.NCTID() != "" {
+			studies = append(studies, study)
+		}
+	}
+	if len(studies) == 0 {
+		return nil, ErrNoStudies
+	}
+	return studies, nil
+}
+
+// NCTID Номер исследования в ClinicalTrials.gov
+func (s *Study) NCTID() string {
+	return strings.TrimSpace(s.ProtocolSection.IdentificationModule.NCTID)
+}
+
+// Trial Описание исследования на английском языке в формате реестра.
+// Если country не пуст, в центры попадают только центры страны, название которой его содержит
+// ("Russia" подходит и для "Russian Federation")
+func (s *Study) Trial(country string) service.Trial {
+	protocol := &s.ProtocolSection
+	inclusion, exclusion := parseEligibility(protocol.EligibilityModule.EligibilityCriteria)
+
+	trial := service.Trial{
+		Code:       s.NCTID(),
+		NCT:        s.NCTID(),
+		Title:      strings.TrimSpace(protocol.IdentificationModule.BriefTitle),
+		Sponsor:    strings.TrimSpace(protocol.SponsorCollaboratorsModule.LeadSponsor.Name),
+		Phase:      phase(protocol.DesignModule.Phases),
+		Conditions: protocol.ConditionsModule.Conditions,
+		Inclusion:  inclusion,
+		Exclusion:  exclusion,
+		Status:     ctgovStatus(protocol.StatusModule.OverallStatus),
+	}
+	for _, location := range protocol.ContactsLocationsModule.Locations {
+		if !strings.Contains(strings.ToLower(location.Country), strings.ToLower(country)) {
+			continue
+		}
+		trial.Sites = append(trial.Sites, service.Site{
+			Name: location.Facility,
+			City: location.City,
+			Lat:  location.GeoPoint.Lat,
+			Lon:  location.GeoPoint.Lon,
+		})
+	}
+	return trial
+}
+
+// MergeStudies дополняет реестр данными ClinicalTrials.gov, сопоставляя исследования по номеру NCT.
+// Русские тексты реестра не перезаписываются: английское описание попадает в перевод "en",
+// а пустые поля (спонсор, фаза, заболевания, центры, статус) заполняются из записи.
+// local — исследования из файла опросника: найденное только там исследование переносится в реестр вместе с текстами.
+// Исследования, которых нет ни в реестре, ни в опроснике, добавляются с кодом, равным номеру NCT
+func MergeStudies(registry, local, studies []service.Trial) []service.Trial {
+	merged := append([]service.Trial{}, registry...)
+
+	for _, study := range studies {
+		existing := findNCT(merged, study.NCT)
+		if existing == nil {
+			if trial := findNCT(local, study.NCT); trial != nil {
+				merged = append(merged, *trial)
+				existing = &merged[len(merged)-1]
+			}
+		}
+		if existing == nil {
+			merged = append(merged, study)
+			continue
+		}
+		mergeStudy(existing, study)
+	}
+	return merged
+}
+
+func mergeStudy(trial *service.Trial, study service.Trial) {
+	trial.NCT = study.NCT
+
+	english := trial.I18n["en"]
+	if english.Title == "" {
+		english.Title = study.Title
+	}
+	if len(english.Inclusion) == 0 {
+		english.Inclusion = study.Inclusion
+	}
+	if len(english.Exclusion) == 0 {
+		english.Exclusion = study.Exclusion
+	}
+	translations := map[string]service.TrialText{}
+	for lang, text := range trial.I18n {
+		translations[lang] = text
+	}
+	translations["en"] = english
+	trial.I18n = translations
+
+	if trial.Title == "" {
+		trial.Title = study.Title
+	}
+	if len(trial.Inclusion) == 0 {
+		trial.Inclusion = study.Inclusion
+	}
+	if len(trial.Exclusion) == 0 {
+		trial.Exclusion = study.Exclusion
+	}
+	if trial.Sponsor == "" {
+		trial.Sponsor = study.Sponsor
+	}
+	if trial.Phase == "" {
+		trial.Phase = study.Phase
+	}
+	if len(trial.Conditions) == 0 {
+		trial.Conditions = study.Conditions
+	}
+	if len(trial.Sites) == 0 {
+		trial.Sites = study.Sites
+	}
+	if trial.Status == "" {
+		trial.Status = study.Status
+	}
+}
+
+// findNCT Исследование по номеру NCT, у исследований без номера им считается код
+func findNCT(trials []service.Trial, nct string) *service.Trial {
+	for i := range trials {
+		if strings.EqualFold(trials[i].NCT, nct) || (trials[i].NCT == "" && strings.EqualFold(trials[i].Code, nct)) {
+			return &trials[i]
+		}
+	}
+	return nil
+}
+
+// phase Фаза в записи реестра: ["PHASE2", "PHASE3"] -> "II/III"
+func phase(phases []string) string {
+	var parts []string
+	for _, p := range phases {
+		if roman, ok := ctgovPhases[p]; ok && !slices.Contains(parts, roman) {
+			parts = append(parts, roman)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+var (
+	// criteriaHeader Заголовок раздела критериев: "Inclusion Criteria:", "Key Exclusion Criteria:"
+	criteriaHeader = regexp.MustCompile(`(?i)^(?:key\s+)?(inclusion|exclusion)\s+criteria\b`)
+	// criteriaItem Пункт списка критериев в разметке ClinicalTrials.gov
+	criteriaItem = regexp.MustCompile(`^(?:[*\-•]|\d+[.)])\s+`)
+)
+
+// parseEligibility Разбивает текст критериев ClinicalTrials.gov на критерии включения и невключения.
+// Строка без маркера списка продолжает предыдущий пункт
+func parseEligibility(text string) (inclusion, exclusion []string) {
+	var section *[]string
+	continued := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, `\>`, ">"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continued = false
+			continue
+		}
+
+		if match := criteriaHeader.FindStringSubmatch(line); match != nil {
+			if strings.EqualFold(match[1], "inclusion") {
+				section = &inclusion
+			} else {
+				section = &exclusion
+			}
+			continued = false
+			continue
+		}
+		if section == nil {
+			continue
+		}
+
+		if item := criteriaItem.ReplaceAllString(line, ""); item != line || !continued {
+			*section = append(*section, item)
+			continued = true
+			continue
+		}
+		(*section)[len(*section)-1] += " " + line
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"telegram-bot/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const studyJSON = `{
+  "protocolSection": {
+    "identificationModule": {"nctId": "NCT01234567", "briefTitle": "Study of Drug X in NSCLC"},
+    "statusModule": {"overallStatus": "RECRUITING"},
+    "sponsorCollaboratorsModule": {"leadSponsor": {"name": "Pharma Inc."}},
+    "conditionsModule": {"conditions": ["Non-small Cell Lung Cancer"]},
+    "designModule": {"phases": ["PHASE2", "PHASE3"]},
+    "eligibilityModule": {
+      "eligibilityCriteria": "Inclusion Criteria:\n\n* Age ≥ 18 years\n* ECOG 0-1\n  continued line\n\nExclusion Criteria:\n\n1. Pregnancy\n2. Brain metastases \\> 1 cm"
+    },
+    "contactsLocationsModule": {
+      "locations": [
+        {"facility": "N.N. Blokhin Cancer Center", "city": "Moscow", "country": "Russian Federation", "geoPoint": {"lat": 55.66, "lon": 37.64}},
+        {"facility": "MD Anderson", "city": "Houston", "country": "United States"}
+      ]
+    }
+  }
+}`
+
+func TestReadClinicalTrials(t *testing.T) {
+	studies, err := ReadClinicalTrials(strings.NewReader(studyJSON))
+	if !assert.NoError(t, err) || !assert.Len(t, studies, 1) {
+		return
+	}
+
+	trial := studies[0].Trial("Russia")
+	assert.Equal(t, service.Trial{
+		Code:       "NCT01234567",
+		NCT:        "NCT01234567",
+		Title:      "Study of Drug X in NSCLC",
+		Sponsor:    "Pharma Inc.",
+		Phase:      "II/III",
+		Conditions: []string{"Non-small Cell Lung Cancer"},
+		Inclusion:  []string{"Age ≥ 18 years", "ECOG 0-1 continued line"},
+		Exclusion:  []string{"Pregnancy", "Brain metastases > 1 cm"},
+		Sites:      []service.Site{{Name: "N.N. Blokhin Cancer Center", City: "Moscow", Lat: 55.66, Lon: 37.64}},
+		Status:     service.StatusRecruiting,
+	}, trial)
+	assert.Len(t, studies[0].Trial("").Sites, 2, "Без страны импортируются все центры")
+
+	studies, err = ReadClinicalTrials(strings.NewReader(`{"studies": [` + studyJSON + `, {"protocolSection": {}}]}`))
+	assert.NoError(t, err)
+	assert.Len(t, studies, 1, "Ответ поиска, записи без номера пропускаются")
+
+	_, err = ReadClinicalTrials(strings.NewReader(`{"studies": []}`))
+	assert.ErrorIs(t, err, ErrNoStudies)
+
+	_, err = ReadClinicalTrials(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+func TestMergeStudies(t *testing.T) {
+	study := service.Trial{
+		Code:      "NCT01234567",
+		NCT:       "NCT01234567",
+		Title:     "Study of Drug X",
+		Sponsor:   "Pharma Inc.",
+		Phase:     "III",
+		Inclusion: []string{"Age ≥ 18 years"},
+		Exclusion: []string{"Pregnancy"},
+		Sites:     []service.Site{{Name: "Blokhin", City: "Moscow"}},
+		Status:    service.StatusClosed,
+	}
+	registry := []service.Trial{{
+		Code:      "DRX-301",
+		NCT:       "nct01234567",
+		Title:     "Исследование препарата X",
+		Inclusion: []string{"Возраст ≥ 18 лет"},
+		Status:    service.StatusPaused,
+		I18n:      map[string]service.TrialText{"en": {Title: "Local English title"}},
+	}}
+
+	merged := MergeStudies(registry, nil, []service.Trial{study})
+	if !assert.Len(t, merged, 1) {
+		return
+	}
+	trial := merged[0]
+	assert.Equal(t, "DRX-301", trial.Code)
+	assert.Equal(t, "Исследование препарата X", trial.Title, "Русское название сохраняется")
+	assert.Equal(t, []string{"Возраст ≥ 18 лет"}, trial.Inclusion)
+	assert.Equal(t, []string{"Pregnancy"}, trial.Exclusion, "Пустые критерии заполняются из записи")
+	assert.Equal(t, "Pharma Inc.", trial.Sponsor)
+	assert.Equal(t, service.StatusPaused, trial.Status, "Локальный статус сохраняется")
+	assert.Equal(t, service.TrialText{
+		Title:     "Local English title",
+		Inclusion: []string{"Age ≥ 18 years"},
+		Exclusion: []string{"Pregnancy"},
+	}, trial.I18n["en"], "Английский текст дополняет перевод")
+	assert.Equal(t, "Local English title", registry[0].I18n["en"].Title)
+	assert.Empty(t, registry[0].I18n["en"].Inclusion, "Исходный реестр не меняется")
+
+	local := []service.Trial{{Code: "NCT01234567", Title: "Из опросника", Inclusion: []string{"Критерий"}}}
+	merged = MergeStudies(nil, local, []service.Trial{study})
+	if assert.Len(t, merged, 1) {
+		assert.Equal(t, "Из опросника", merged[0].Title, "Исследование из опросника переносится в реестр с русским текстом")
+		assert.Equal(t, "NCT01234567", merged[0].NCT)
+	}
+
+	merged = MergeStudies(nil, nil, []service.Trial{study})
+	if assert.Len(t, merged, 1) {
+		assert.Equal(t, "NCT01234567", merged[0].Code, "Новое исследование добавляется с кодом NCT")
+	}
+}
+
+func TestCtgovStatus(t *testing.T) {
+	assert.Equal(t, service.StatusRecruiting, ctgovStatus("RECRUITING"))
+	assert.Equal(t, service.StatusPaused, ctgovStatus("NOT_YET_RECRUITING"), "Набор еще не открыт")
+	assert.Equal(t, service.StatusClosed, ctgovStatus("COMPLETED"))
+	assert.Equal(t, service.StatusPaused, ctgovStatus("WITHHELD"), "Неизвестный статус не считается открытым набором")
+	assert.Equal(t, service.StatusPaused, ctgovStatus(""))
+}
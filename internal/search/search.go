@@ -0,0 +1,170 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"telegram-bot/internal/service"
+)
+
+// Веса полей исследования при ранжировании
+const (
+	codeWeight     = 5.0
+	titleWeight    = 3.0
+	criteriaWeight = 1.0
+)
+
+// minPrefixLen Минимальная длина слова запроса для поиска по началу слова ("her" → "her2")
+const minPrefixLen = 3
+
+// Result Найденное исследование с оценкой релевантности
+type Result struct {
+	Trial *service.Trial
+	Score float64
+}
+
+// document Слова полей исследования
+type document struct {
+	trial  *service.Trial
+	fields []field
+}
+
+type field struct {
+	weight float64
+	terms  map[string]bool
+}
+
+// Search ищет исследования по коду, названию и критериям (включая переводы)
+// и возвращает не больше limit лучших результатов
+func Search(trials []service.Trial, query string, limit int) (results []Result) {
+	terms := uniqueTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	documents := make([]document, len(trials))
+	for i := range trials {
+		documents[i] = newDocument(&trials[i])
+	}
+
+	// Редкие слова важнее частых
+	frequency := map[string]int{}
+	for _, term := range terms {
+		for _, doc := range documents {
+			if doc.matches(term) > 0 {
+				frequency[term]++
+			}
+		}
+	}
+
+	normalizedQuery := strings.ToLower(strings.TrimSpace(query))
+	for _, doc := range documents {
+		var score float64
+		matched := 0
+		for _, term := range terms {
+			if frequency[term] == 0 {
+				continue
+			}
+			if weight := doc.matches(term); weight > 0 {
+				matched++
+				score += weight * math.Log(1+float64(len(documents))/float64(frequency[term]))
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+
+		// Документы, совпавшие со всеми словами запроса, выше частичных совпадений
+		score *= float64(matched) / float64(len(terms))
+		if strings.ToLower(doc.trial.Code) == normalizedQuery {
+			score += 100
+		}
+		results = append(results, Result{Trial: doc.trial, Score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Trial.Code < results[j].Trial.Code
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return
+}
+
+func newDocument(trial *service.Trial) document {
+	title := []string{trial.Title}
+	criteria := append(append([]string{}, trial.Inclusion...), trial.Exclusion...)
+	for _, text := range trial.I18n {
+		title = append(title, text.Title)
+		criteria = append(append(criteria, text.Inclusion...), text.Exclusion...)
+	}
+
+	return document{
+		trial: trial,
+		fields: []field{
+			{weight: codeWeight, terms: termSet(trial.Code)},
+			{weight: titleWeight, terms: termSet(title...)},
+			{weight: criteriaWeight, terms: termSet(criteria...)},
+		},
+	}
+}
+
+// matches Вес самого важного поля, содержащего слово. Совпадение по началу слова весит вдвое меньше
+func (d document) matches(term string) (weight float64) {
+	for _, f := range d.fields {
+		if f.terms[term] {
+			weight = math.Max(weight, f.weight)
+			continue
+		}
+		if len([]rune(term)) < minPrefixLen {
+			continue
+		}
+		for docTerm := range f.terms {
+			if strings.HasPrefix(docTerm, term) {
+				weight = math.Max(weight, f.weight/2)
+				break
+			}
+		}
+	}
+	return
+}
+
+// Tokenize разбивает текст на слова и приводит их к основе
+func Tokenize(text string) (terms []string) {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if stem := Stem(word); stem != "" {
+			terms = append(terms, stem)
+		}
+	}
+	return
+}
+
+func termSet(texts ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, text := range texts {
+		for _, term := range Tokenize(text) {
+			set[term] = true
+		}
+	}
+	return set
+}
+
+func uniqueTerms(text string) (terms []string) {
+	seen := map[string]bool{}
+	for _, term := range Tokenize(text) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return
+}
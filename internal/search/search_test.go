@@ -0,0 +1,60 @@
+package search
+
+import (
+	"testing"
+
+	"telegram-bot/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	for word, expected := range map[string]string{
+		"меланома":       "меланом",
+		"меланомы":       "меланом",
+		"меланомой":      "меланом",
+		"легкого":        "легк",
+		"лёгких":         "легк",
+		"метастазами":    "метастаз",
+		"исследования":   "исследован",
+		"терапии":        "терап",
+		"красивейшими":   "красив",
+		"вдохновенность": "вдохновен",
+		"бегавшись":      "бега",
+		"HER2":           "her2",
+		"PD":             "pd",
+	} {
+		assert.Equal(t, expected, Stem(word), word)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"рак", "молочн", "желез", "her2"}, Tokenize("Рак молочной железы, HER2+"))
+	assert.Empty(t, Tokenize(" — "))
+}
+
+func TestSearch(t *testing.T) {
+	trials := []service.Trial{
+		{Code: "MEL-1", Title: "Исследование при меланоме", Inclusion: []string{"Метастатическая меланома кожи"}},
+		{Code: "LUNG-2", Title: "Исследование при раке легкого", Inclusion: []string{"Мутация EGFR", "Отсутствие меланомы в анамнезе"}},
+		{Code: "HER2-3", Title: "Рак молочной железы", Inclusion: []string{"HER2-положительный статус"},
+			I18n: map[string]service.TrialText{"en": {Title: "Breast cancer study"}}},
+	}
+
+	codes := func(results []Result) (codes []string) {
+		for _, result := range results {
+			codes = append(codes, result.Trial.Code)
+		}
+		return
+	}
+
+	assert.Equal(t, []string{"MEL-1", "LUNG-2"}, codes(Search(trials, "меланомы", 0)), "Название важнее критериев")
+	assert.Equal(t, []string{"HER2-3"}, codes(Search(trials, "her2", 0)))
+	assert.Equal(t, []string{"HER2-3"}, codes(Search(trials, "HER", 0)), "Поиск по началу слова")
+	assert.Equal(t, []string{"LUNG-2"}, codes(Search(trials, "лёгкое", 0)))
+	assert.Equal(t, []string{"HER2-3"}, codes(Search(trials, "breast", 0)), "Поиск по переводу")
+	assert.Equal(t, []string{"LUNG-2", "MEL-1"}, codes(Search(trials, "меланома EGFR", 0)), "Совпадение всех слов выше")
+	assert.Equal(t, []string{"MEL-1"}, codes(Search(trials, "меланома", 1)))
+	assert.Empty(t, Search(trials, "глиобластома", 0))
+	assert.Empty(t, Search(trials, "", 0))
+}
@@ -0,0 +1,178 @@
+package search
+
+import (
+	"strings"
+)
+
+// ending Окончание стеммера. afterA - окончание удаляется, только если перед ним стоит а или я
+type ending struct {
+	text   string
+	afterA bool
+}
+
+// endings Составляет класс окончаний из двух групп: после а/я и без условия
+func endings(afterA []string, plain ...string) (class []ending) {
+	for _, text := range afterA {
+		class = append(class, ending{text: text, afterA: true})
+	}
+	for _, text := range plain {
+		class = append(class, ending{text: text})
+	}
+	return
+}
+
+// Классы окончаний русского стеммера Snowball
+var (
+	perfectiveGerund = endings(
+		[]string{"в", "вши", "вшись"},
+		"ив", "ивши", "ившись", "ыв", "ывши", "ывшись",
+	)
+	adjective = endings(nil,
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	)
+	participle = endings(
+		[]string{"ем", "нн", "вш", "ющ", "щ"},
+		"ивш", "ывш", "ующ",
+	)
+	reflexive = endings(nil, "ся", "сь")
+	verb      = endings(
+		[]string{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"},
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	)
+	noun = endings(nil,
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+	)
+	superlative  = endings(nil, "ейш", "ейше")
+	derivational = endings(nil, "ост", "ость")
+)
+
+const russianVowels = "аеиоуыэюя"
+
+// Stem возвращает основу русского слова по алгоритму Snowball.
+// Слова не на кириллице (коды, названия генов) возвращаются в нижнем регистре без изменений
+func Stem(word string) string {
+	word = strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	if !isCyrillic(word) {
+		return word
+	}
+
+	runes := []rune(word)
+	rv := regionAfterVowel(runes)
+	r1 := regionAfterConsonant(runes, 0)
+	r2 := regionAfterConsonant(runes, r1)
+
+	// Шаг 1: деепричастие или возвратная частица и окончание прилагательного, глагола, существительного
+	if stem, ok := removeEnding(runes, rv, perfectiveGerund); ok {
+		runes = stem
+	} else {
+		if stem, ok = removeEnding(runes, rv, reflexive); ok {
+			runes = stem
+		}
+		if stem, ok = removeAdjectival(runes, rv); ok {
+			runes = stem
+		} else if stem, ok = removeEnding(runes, rv, verb); ok {
+			runes = stem
+		} else if stem, ok = removeEnding(runes, rv, noun); ok {
+			runes = stem
+		}
+	}
+
+	// Шаг 2
+	if hasSuffixIn(runes, rv, "и") {
+		runes = runes[:len(runes)-1]
+	}
+
+	// Шаг 3: словообразовательный суффикс
+	if stem, ok := removeEnding(runes, r2, derivational); ok {
+		runes = stem
+	}
+
+	// Шаг 4: двойное н, превосходная степень, мягкий знак
+	if hasSuffixIn(runes, rv, "нн") {
+		runes = runes[:len(runes)-1]
+	} else if stem, ok := removeEnding(runes, rv, superlative); ok {
+		runes = stem
+		if hasSuffixIn(runes, rv, "нн") {
+			runes = runes[:len(runes)-1]
+		}
+	} else if hasSuffixIn(runes, rv, "ь") {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes)
+}
+
+// removeAdjectival удаляет окончание прилагательного вместе с суффиксом причастия, если он есть
+func removeAdjectival(runes []rune, rv int) ([]rune, bool) {
+	stem, ok := removeEnding(runes, rv, adjective)
+	if !ok {
+		return runes, false
+	}
+
+	if withoutParticiple, ok := removeEnding(stem, rv, participle); ok {
+		return withoutParticiple, true
+	}
+	return stem, true
+}
+
+// removeEnding удаляет самое длинное окончание класса, лежащее в регионе start.
+// Если для самого длинного окончания не выполнено условие а/я, слово не меняется
+func removeEnding(runes []rune, start int, class []ending) ([]rune, bool) {
+	var longest *ending
+	for i := range class {
+		if hasSuffixIn(runes, start, class[i].text) && (longest == nil || len(class[i].text) > len(longest.text)) {
+			longest = &class[i]
+		}
+	}
+	if longest == nil {
+		return runes, false
+	}
+
+	cut := len(runes) - len([]rune(longest.text))
+	if longest.afterA && (cut-1 < start || (runes[cut-1] != 'а' && runes[cut-1] != 'я')) {
+		return runes, false
+	}
+	return runes[:cut], true
+}
+
+// hasSuffixIn проверяет, что слово оканчивается на suffix и окончание лежит в регионе start
+func hasSuffixIn(runes []rune, start int, suffix string) bool {
+	cut := len(runes) - len([]rune(suffix))
+	return cut >= start && string(runes[cut:]) == suffix
+}
+
+// regionAfterVowel Начало региона после первой гласной (RV)
+func regionAfterVowel(runes []rune) int {
+	for i, r := range runes {
+		if isVowel(r) {
+			return i + 1
+		}
+	}
+	return len(runes)
+}
+
+// regionAfterConsonant Начало региона после первой согласной, следующей за гласной (R1, R2)
+func regionAfterConsonant(runes []rune, from int) int {
+	for i := from + 1; i < len(runes); i++ {
+		if !isVowel(runes[i]) && isVowel(runes[i-1]) {
+			return i + 1
+		}
+	}
+	return len(runes)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune(russianVowels, r)
+}
+
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if r >= 'а' && r <= 'я' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Служебные значения callback data, которые не могут использоваться вариантами ответа
+const (
+	CallbackStart = "start"
+	CallbackBack  = "back"
+	CallbackDone  = "done" // завершение множественного выбора
+
+	// Ответы на критерии в проверке критериев исследования
+	CallbackCheckYes     = "check_yes"
+	CallbackCheckNo      = "check_no"
+	CallbackCheckUnknown = "check_unknown"
+	CallbackCheckBack    = "check_back"
+)
+
+// CallbackResultsPrefix Префикс callback data списка исследований конечного варианта ответа:
+// "results:<data>" - список, "results:<data>:<код исследования>" - карточка исследования из списка.
+// Карточка определяется кодом, а не номером в списке: после обновления опросника номер
+// может указывать на другое исследование
+const CallbackResultsPrefix = "results:"
+
+// Префиксы callback data, после которых идет код исследования
+const (
+	CallbackTrialPrefix = "trial:" // карточка исследования
+	CallbackCheckPrefix = "check:" // начало проверки критериев исследования
+	CallbackNearPrefix  = "near:"  // поиск ближайшего центра исследования
+	CallbackDocPrefix   = "doc:"   // отправка документов исследования
+)
+
+// CallbackPagePrefix Префикс callback data страницы длинной карточки исследования:
+// "page:<номер>:<callback data карточки>"
+const CallbackPagePrefix = "page:"
+
+// CallbackLanguagePrefix Префикс callback data выбора языка интерфейса: "lang:<код языка>"
+const CallbackLanguagePrefix = "lang:"
+
+// MaxCallbackDataLen Ограничение Telegram на длину callback data в байтах
+const MaxCallbackDataLen = 64
+
+// ReservedCallbackData Список зарезервированных значений callback data
+var ReservedCallbackData = []string{
+	CallbackStart,
+	CallbackBack,
+	CallbackDone,
+	CallbackCheckYes,
+	CallbackCheckNo,
+	CallbackCheckUnknown,
+	CallbackCheckBack,
+}
+
+// ReservedCallbackPrefixes Список зарезервированных префиксов callback data
+var ReservedCallbackPrefixes = []string{
+	CallbackResultsPrefix,
+	CallbackTrialPrefix,
+	CallbackCheckPrefix,
+	CallbackLanguagePrefix,
+	CallbackNearPrefix,
+	CallbackPagePrefix,
+	CallbackDocPrefix,
+}
+
+// IsReservedCallbackData проверяет, занято ли значение callback data ботом
+func IsReservedCallbackData(data string) bool {
+	for _, reserved := range ReservedCallbackData {
+		if data == reserved {
+			return true
+		}
+	}
+	for _, prefix := range ReservedCallbackPrefixes {
+		if strings.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResultsCallbackData Формирует callback data карточки исследования code из списка или самого списка (code == "")
+func ResultsCallbackData(optionData, code string) string {
+	if code == "" {
+		return CallbackResultsPrefix + optionData
+	}
+	return CallbackResultsPrefix + optionData + ":" + code
+}
+
+// ParseResultsCallbackData Разбирает параметр callback data списка исследований: конечный вариант
+// ответа и код исследования (пустой для самого списка). И data, и код могут содержать ":",
+// поэтому граница ищется по вариантам ответа опросника survey. Если варианта нет, option равен nil
+func ParseResultsCallbackData(survey *Survey, payload string) (option *Option, code string) {
+	if option = survey.FindOption(payload); option != nil && option.IsTerminal() {
+		return option, ""
+	}
+	for sep := range payload {
+		if payload[sep] != ':' {
+			continue
+		}
+		if option = survey.FindOption(payload[:sep]); option != nil && option.IsTerminal() {
+			return option, payload[sep+1:]
+		}
+	}
+	return nil, ""
+}
+
+// PageCallbackData Формирует callback data страницы карточки исследования
+func PageCallbackData(cardData string, page int) string {
+	return CallbackPagePrefix + strconv.Itoa(page) + ":" + cardData
+}
+
+// ParsePageCallbackData Разбирает параметр callback data страницы карточки исследования
+func ParsePageCallbackData(payload string) (cardData string, page int, ok bool) {
+	number, cardData, found := strings.Cut(payload, ":")
+	if !found {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(number)
+	if err != nil || page < 0 {
+		return "", 0, false
+	}
+	return cardData, page, true
+}
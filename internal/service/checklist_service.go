@@ -0,0 +1,78 @@
+package service
+
+// CriterionAnswer Ответ врача на критерий отбора
+type CriterionAnswer string
+
+const (
+	AnswerYes     CriterionAnswer = "yes"
+	AnswerNo      CriterionAnswer = "no"
+	AnswerUnknown CriterionAnswer = "unknown"
+)
+
+// Criterion Критерий отбора исследования
+type Criterion struct {
+	Text      string
+	Exclusion bool // Критерий невключения: пациент не должен ему соответствовать
+}
+
+// Fails проверяет, исключает ли ответ пациента из исследования
+func (c Criterion) Fails(answer CriterionAnswer) bool {
+	if c.Exclusion {
+		return answer == AnswerYes
+	}
+	return answer == AnswerNo
+}
+
+// Criteria возвращает критерии исследования: сначала включения, затем невключения
+func (t *Trial) Criteria() (criteria []Criterion) {
+	for _, text := range t.Inclusion {
+		criteria = append(criteria, Criterion{Text: text})
+	}
+	for _, text := range t.Exclusion {
+		criteria = append(criteria, Criterion{Text: text, Exclusion: true})
+	}
+	return
+}
+
+// VerdictKind Итог проверки критериев
+type VerdictKind int
+
+const (
+	VerdictEligible    VerdictKind = iota // вероятно подходит
+	VerdictNotEligible                    // не подходит
+	VerdictNeedsData                      // нужны дополнительные данные
+)
+
+// Verdict Итог проверки критериев с перечнем проблемных критериев
+type Verdict struct {
+	Kind    VerdictKind
+	Failed  []Criterion // критерии, по которым пациент не подходит
+	Unknown []Criterion // критерии, по которым нет данных
+}
+
+// EvaluateChecklist подводит итог проверки критериев по ответам врача
+func EvaluateChecklist(criteria []Criterion, answers []CriterionAnswer) (verdict Verdict) {
+	for i, criterion := range criteria {
+		answer := AnswerUnknown
+		if i < len(answers) {
+			answer = answers[i]
+		}
+
+		switch {
+		case criterion.Fails(answer):
+			verdict.Failed = append(verdict.Failed, criterion)
+		case answer == AnswerUnknown:
+			verdict.Unknown = append(verdict.Unknown, criterion)
+		}
+	}
+
+	switch {
+	case len(verdict.Failed) > 0:
+		verdict.Kind = VerdictNotEligible
+	case len(verdict.Unknown) > 0:
+		verdict.Kind = VerdictNeedsData
+	default:
+		verdict.Kind = VerdictEligible
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateChecklist(t *testing.T) {
+	trial := &Trial{
+		Inclusion: []string{"ECOG 0-1", "Измеримый очаг"},
+		Exclusion: []string{"Метастазы в ЦНС"},
+	}
+	criteria := trial.Criteria()
+
+	verdict := EvaluateChecklist(criteria, []CriterionAnswer{AnswerYes, AnswerYes, AnswerNo})
+	assert.Equal(t, VerdictEligible, verdict.Kind)
+
+	verdict = EvaluateChecklist(criteria, []CriterionAnswer{AnswerYes, AnswerUnknown, AnswerNo})
+	assert.Equal(t, VerdictNeedsData, verdict.Kind)
+	assert.Equal(t, []Criterion{{Text: "Измеримый очаг"}}, verdict.Unknown)
+
+	verdict = EvaluateChecklist(criteria, []CriterionAnswer{AnswerNo, AnswerUnknown, AnswerYes})
+	assert.Equal(t, VerdictNotEligible, verdict.Kind)
+	assert.Equal(t, []Criterion{{Text: "ECOG 0-1"}, {Text: "Метастазы в ЦНС", Exclusion: true}}, verdict.Failed)
+}
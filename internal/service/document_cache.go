@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentCache Кэш file_id документов, уже загруженных в Telegram: каждый файл загружается ботом один раз.
+// Ключ учитывает размер и время изменения файла, поэтому обновленный файл загружается заново.
+// Если указан путь, кэш сохраняется на диск и переживает перезапуск бота
+type DocumentCache struct {
+	mu   sync.Mutex
+	path string
+	ids  map[string]string
+}
+
+// NewDocumentCache создает кэш и читает сохраненные file_id из файла path (если он указан и существует)
+func NewDocumentCache(path string) (*DocumentCache, error) {
+	cache := &DocumentCache{path: path, ids: map[string]string{}}
+	if path == "" {
+		return cache, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("READ DOCUMENT CACHE: %w", err)
+	}
+	if err = yaml.Unmarshal(data, &cache.ids); err != nil {
+		return cache, fmt.Errorf("PARSE DOCUMENT CACHE %s: %w", path, err)
+	}
+	if cache.ids == nil {
+		cache.ids = map[string]string{}
+	}
+	return cache, nil
+}
+
+// Get возвращает file_id загруженного файла или пустую строку, если файл еще не загружался или изменился
+func (c *DocumentCache) Get(file string) string {
+	key, err := documentKey(file)
+	if err != nil {
+		return ""
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ids[key]
+}
+
+// Set запоминает file_id загруженного файла
+func (c *DocumentCache) Set(file, fileID string) error {
+	key, err := documentKey(file)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ids[key] = fileID
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := yaml.Marshal(c.ids)
+	if err != nil {
+		return fmt.Errorf("MARSHAL DOCUMENT CACHE: %w", err)
+	}
+	if err = os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("WRITE DOCUMENT CACHE: %w", err)
+	}
+	return nil
+}
+
+// documentKey Ключ кэша: путь, размер и время изменения файла
+func documentKey(file string) (string, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", fmt.Errorf("STAT DOCUMENT %s: %w", file, err)
+	}
+	return file + "|" + strconv.FormatInt(info.Size(), 10) + "|" + strconv.FormatInt(info.ModTime().UnixNano(), 10), nil
+}
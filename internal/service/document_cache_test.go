@@ -0,0 +1,104 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentCache(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "synopsis.pdf")
+	if err := os.WriteFile(file, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cachePath := filepath.Join(dir, "documents.yaml")
+
+	cache, err := NewDocumentCache(cachePath)
+	if !assert.NoError(t, err, "Файла кэша еще нет") {
+		return
+	}
+	assert.Empty(t, cache.Get(file))
+	assert.NoError(t, cache.Set(file, "file-1"))
+	assert.Equal(t, "file-1", cache.Get(file))
+
+	// Кэш сохраняется на диск и читается после перезапуска
+	cache, err = NewDocumentCache(cachePath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "file-1", cache.Get(file))
+
+	// Измененный файл загружается заново
+	if err = os.WriteFile(file, []byte("%PDF-1.7 updated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, cache.Get(file))
+
+	assert.Error(t, cache.Set(filepath.Join(dir, "missing.pdf"), "file-2"), "Отсутствующий файл")
+
+	if err = os.WriteFile(cachePath, []byte("not: [yaml"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewDocumentCache(cachePath)
+	assert.Error(t, err, "Поврежденный файл кэша")
+}
+
+func TestDocumentCacheModTime(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "synopsis.pdf")
+	if err := os.WriteFile(file, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, _ := NewDocumentCache("")
+	assert.NoError(t, cache.Set(file, "file-1"))
+
+	// Файл того же размера, замененный позже, тоже считается новым
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, cache.Get(file))
+}
+
+func TestLoadSurveyDocuments(t *testing.T) {
+	path := writeSurveyFile(t, "survey.yaml", surveyYAML+"    documents:\n      - title: Синопсис\n        file: docs/mit-002.pdf\n")
+	dir := filepath.Dir(path)
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "docs", "mit-002.pdf"), []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	survey, err := LoadSurvey(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	trial := survey.GetTrial("MIT-002")
+	if !assert.Len(t, trial.Documents, 1) {
+		return
+	}
+	assert.Equal(t, filepath.Join(dir, "docs", "mit-002.pdf"), trial.Documents[0].Path(), "Путь относительно файла опросника")
+	assert.Equal(t, "docs/mit-002.pdf", trial.Documents[0].File, "Путь из файла не меняется")
+	assert.Empty(t, survey.Validate())
+
+	// Импорт сохраняет исследования в реестр рядом с опросником с исходными путями
+	registryPath := filepath.Join(dir, "trials.yaml")
+	assert.NoError(t, SaveTrials(registryPath, survey.Trials))
+	registry, err := LoadTrials(registryPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "docs/mit-002.pdf", registry[len(registry)-1].Documents[0].File)
+	}
+
+	trial.Documents = append(trial.Documents, Document{File: filepath.Join(dir, "docs", "missing.pdf")})
+	var messages []string
+	for _, problem := range survey.Validate() {
+		messages = append(messages, problem.String())
+	}
+	assert.Contains(t, strings.Join(messages, "\n"), "missing.pdf")
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"math"
+	"sort"
+)
+
+// earthRadiusKm Средний радиус Земли в километрах
+const earthRadiusKm = 6371.0
+
+// SiteDistance Исследовательский центр и расстояние до него в километрах
+type SiteDistance struct {
+	Site     *Site
+	Distance float64
+}
+
+// Distance возвращает расстояние по большому кругу между двумя точками в километрах (формула гаверсинусов)
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// HasLocation проверяет, указаны ли координаты центра
+func (s *Site) HasLocation() bool {
+	return s.Lat != 0 || s.Lon != 0
+}
+
+// HasSiteLocations проверяет, есть ли у исследования центры с координатами
+func (t *Trial) HasSiteLocations() bool {
+	for i := range t.Sites {
+		if t.Sites[i].HasLocation() {
+			return true
+		}
+	}
+	return false
+}
+
+// NearestSites возвращает не более limit центров с координатами, упорядоченных по расстоянию до точки
+func (t *Trial) NearestSites(lat, lon float64, limit int) (nearest []SiteDistance) {
+	for i := range t.Sites {
+		site := &t.Sites[i]
+		if site.HasLocation() {
+			nearest = append(nearest, SiteDistance{Site: site, Distance: Distance(lat, lon, site.Lat, site.Lon)})
+		}
+	}
+
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return nearest[i].Distance < nearest[j].Distance
+	})
+	if len(nearest) > limit {
+		nearest = nearest[:limit]
+	}
+	return
+}
@@ -2,14 +2,16 @@ package service
 
 // Option Структура для варианта ответа
 type Option struct {
-	Text         string
-	Data         string
-	NextQuestion *Question // Следующий вопрос (если есть)
-	Result       string    // Итоговый результат (если это конечный ответ)
+	Text         string       `yaml:"text"`
+	Data         string       `yaml:"data"`
+	NextQuestion *Question    `yaml:"next_question,omitempty"` // Следующий вопрос (если есть)
+	Next         string       `yaml:"next,omitempty"`          // ID общего вопроса, подставляется в NextQuestion при загрузке
+	Trials       []string     `yaml:"trials,omitempty"`        // Коды исследований из реестра (если это конечный ответ)
+	I18n         Translations `yaml:"i18n,omitempty"`          // Текст кнопки на других языках
 }
 
 func (o *Option) IsTerminal() bool {
-	return o.Result != ""
+	return len(o.Trials) > 0
 }
 
 func (o *Option) GetNextQuestion() *Question {
@@ -1,8 +1,155 @@
 package service
 
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QuestionType Тип вопроса
+type QuestionType string
+
+const (
+	QuestionChoice QuestionType = "choice" // выбор одного варианта кнопкой (по умолчанию)
+	QuestionNumber QuestionType = "number" // ввод числа сообщением
+	QuestionMulti  QuestionType = "multi"  // выбор нескольких вариантов с кнопкой "Готово"
+)
+
 // Question Структура для вопроса
 type Question struct {
-	ID      string
-	Text    string
-	Options []Option
+	ID      string       `yaml:"id"`
+	Text    string       `yaml:"text"`
+	Type    QuestionType `yaml:"type,omitempty"`
+	Options []Option     `yaml:"options,omitempty"`
+	Input   *NumberInput `yaml:"input,omitempty"`  // Ограничения ввода для вопроса с числом
+	Routes  []Route      `yaml:"routes,omitempty"` // Переходы по введенному числу или выбранным вариантам
+	Rules   []Rule       `yaml:"rules,omitempty"`  // Переходы по ответам на предыдущие вопросы
+	I18n    Translations `yaml:"i18n,omitempty"`   // Текст вопроса на других языках
+}
+
+// NumberInput Ограничения для ввода числа
+type NumberInput struct {
+	Min     *float64 `yaml:"min,omitempty"`
+	Max     *float64 `yaml:"max,omitempty"`
+	Integer bool     `yaml:"integer,omitempty"`
+	Unit    string   `yaml:"unit,omitempty"` // Единицы измерения, например "%" или "кг"
+}
+
+// Route Правило перехода: первое подходящее по порядку правило определяет следующий шаг.
+// Data правила записывается как ответ пользователя
+type Route struct {
+	Min    *float64 `yaml:"min,omitempty"`  // Нижняя граница включительно
+	Max    *float64 `yaml:"max,omitempty"`  // Верхняя граница включительно
+	All    []string `yaml:"all,omitempty"`  // Выбраны все перечисленные варианты
+	Any    []string `yaml:"any,omitempty"`  // Выбран хотя бы один из перечисленных вариантов
+	None   []string `yaml:"none,omitempty"` // Не выбран ни один из перечисленных вариантов
+	Option `yaml:",inline"`
+}
+
+var (
+	ErrNotANumber   = errors.New("NOT A NUMBER")
+	ErrNotInteger   = errors.New("NOT AN INTEGER")
+	ErrOutOfRange   = errors.New("NUMBER OUT OF RANGE")
+	ErrNoRouteMatch = errors.New("NO ROUTE MATCHES ANSWER")
+)
+
+func (q *Question) IsNumber() bool {
+	return q.Type == QuestionNumber
+}
+
+func (q *Question) IsMulti() bool {
+	return q.Type == QuestionMulti
+}
+
+// Targets возвращает все варианты перехода вопроса: кнопки, правила ответа и правила по ответам сессии.
+// Кнопки вопроса с множественным выбором только отмечают варианты и переходов не содержат
+func (q *Question) Targets() (targets []*Option) {
+	if !q.IsMulti() {
+		for i := range q.Options {
+			targets = append(targets, &q.Options[i])
+		}
+	}
+	for i := range q.Routes {
+		targets = append(targets, &q.Routes[i].Option)
+	}
+	for i := range q.Rules {
+		targets = append(targets, &q.Rules[i].Option)
+	}
+	return
+}
+
+// ParseNumber разбирает введенное пользователем число с учетом ограничений вопроса
+func (q *Question) ParseNumber(text string) (value float64, err error) {
+	input := q.Input
+	if input == nil {
+		input = &NumberInput{}
+	}
+
+	text = strings.TrimSpace(text)
+	if input.Unit != "" {
+		text = strings.TrimSpace(strings.TrimSuffix(text, input.Unit))
+	}
+	text = strings.ReplaceAll(strings.ReplaceAll(text, " ", ""), ",", ".")
+
+	value, err = strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, ErrNotANumber
+	}
+	if input.Integer && value != math.Trunc(value) {
+		return 0, ErrNotInteger
+	}
+	if (input.Min != nil && value < *input.Min) || (input.Max != nil && value > *input.Max) {
+		return 0, ErrOutOfRange
+	}
+	return value, nil
+}
+
+// MatchRoute возвращает первое правило, в границы которого попадает значение
+func (q *Question) MatchRoute(value float64) (*Route, error) {
+	for i := range q.Routes {
+		if q.Routes[i].Contains(value) {
+			return &q.Routes[i], nil
+		}
+	}
+	return nil, ErrNoRouteMatch
+}
+
+// MatchSelectionRoute возвращает первое правило, условиям которого удовлетворяют выбранные варианты
+func (q *Question) MatchSelectionRoute(selected []string) (*Route, error) {
+	for i := range q.Routes {
+		if q.Routes[i].MatchesSelection(selected) {
+			return &q.Routes[i], nil
+		}
+	}
+	return nil, ErrNoRouteMatch
+}
+
+// MatchesSelection проверяет условия all/any/none правила для выбранных вариантов
+func (r *Route) MatchesSelection(selected []string) bool {
+	for _, data := range r.All {
+		if !slices.Contains(selected, data) {
+			return false
+		}
+	}
+	for _, data := range r.None {
+		if slices.Contains(selected, data) {
+			return false
+		}
+	}
+	if len(r.Any) == 0 {
+		return true
+	}
+	for _, data := range r.Any {
+		if slices.Contains(selected, data) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains проверяет, попадает ли значение в границы правила
+func (r *Route) Contains(value float64) bool {
+	return (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max)
 }
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(value float64) *float64 {
+	return &value
+}
+
+func TestParseNumber(t *testing.T) {
+	question := &Question{
+		Type:  QuestionNumber,
+		Input: &NumberInput{Min: ptr(0), Max: ptr(100), Unit: "%"},
+	}
+
+	value, err := question.ParseNumber(" 49,5 % ")
+	assert.NoError(t, err)
+	assert.Equal(t, 49.5, value)
+
+	_, err = question.ParseNumber("много")
+	assert.ErrorIs(t, err, ErrNotANumber)
+
+	_, err = question.ParseNumber("120")
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	question.Input.Integer = true
+	_, err = question.ParseNumber("1.5")
+	assert.ErrorIs(t, err, ErrNotInteger)
+}
+
+func TestMatchRoute(t *testing.T) {
+	question := &Question{
+		Type: QuestionNumber,
+		Routes: []Route{
+			{Min: ptr(50), Option: Option{Data: "pdl1_high"}},
+			{Max: ptr(0), Option: Option{Data: "pdl1_negative"}},
+			{Option: Option{Data: "pdl1_low"}},
+		},
+	}
+
+	for value, expected := range map[float64]string{50: "pdl1_high", 99: "pdl1_high", 0: "pdl1_negative", 49.9: "pdl1_low"} {
+		route, err := question.MatchRoute(value)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, route.Data, value)
+		}
+	}
+
+	question.Routes = question.Routes[:1]
+	_, err := question.MatchRoute(10)
+	assert.ErrorIs(t, err, ErrNoRouteMatch)
+}
+
+func TestLoadNumberQuestion(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", `
+questions:
+  - id: q1
+    text: Укажите экспрессию PD-L1
+    type: number
+    input: {min: 0, max: 100, unit: "%"}
+    routes:
+      - {min: 50, data: q1_high, trials: [MIT-002]}
+      - {data: q1_low, trials: [BEV-III/2022]}
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	question := survey.Questions[0]
+	assert.True(t, question.IsNumber())
+	assert.Equal(t, "%", question.Input.Unit)
+
+	route, err := question.MatchRoute(75)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"MIT-002"}, route.Trials)
+	}
+	assert.Same(t, &survey.Questions[0].Routes[1].Option, survey.FindOption("q1_low"))
+}
+
+func TestMatchSelectionRoute(t *testing.T) {
+	question := &Question{
+		Type: QuestionMulti,
+		Routes: []Route{
+			{All: []string{"egfr"}, None: []string{"alk"}, Option: Option{Data: "egfr_only"}},
+			{Any: []string{"alk", "ros1"}, Option: Option{Data: "fusion"}},
+			{None: []string{"egfr", "alk", "ros1"}, Option: Option{Data: "wild_type"}},
+		},
+	}
+
+	for selected, expected := range map[string]string{
+		"egfr":      "egfr_only",
+		"egfr,kras": "egfr_only",
+		"egfr,alk":  "fusion",
+		"ros1":      "fusion",
+		"":          "wild_type",
+		"kras":      "wild_type",
+	} {
+		route, err := question.MatchSelectionRoute(strings.Split(selected, ","))
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, route.Data, selected)
+		}
+	}
+
+	question.Routes = question.Routes[:1]
+	_, err := question.MatchSelectionRoute([]string{"alk"})
+	assert.ErrorIs(t, err, ErrNoRouteMatch)
+}
+
+func TestToggleSelection(t *testing.T) {
+	key := privateSession(903)
+
+	surveyService := GetInstance()
+	surveyService.Start(key)
+	defer surveyService.Reset(key)
+
+	assert.NoError(t, surveyService.ToggleSelection(key, "q1", "egfr"))
+	assert.NoError(t, surveyService.ToggleSelection(key, "q1", "alk"))
+	assert.NoError(t, surveyService.ToggleSelection(key, "q1", "egfr"))
+	assert.Equal(t, []string{"alk"}, surveyService.GetSelection(key, "q1"))
+	assert.Empty(t, surveyService.GetSelection(key, "q2"))
+
+	surveyService.Start(key)
+	assert.Empty(t, surveyService.GetSelection(key, "q1"), "Новый опрос начинается без отметок")
+
+	assert.Error(t, surveyService.ToggleSelection(privateSession(904), "q1", "egfr"), "Нет сессии")
+}
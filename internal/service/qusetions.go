@@ -16,7 +16,7 @@ var Questions = []Question{
 						{
 							Text:   "Трижды негативный",
 							Data:   "q1_1_option1",
-							Result: "AREAL",
+							Trials: []string{"AREAL"},
 						},
 						{
 							Text: "HER2 pos.",
@@ -28,12 +28,12 @@ var Questions = []Question{
 									{
 										Text:   "1 линия терапии",
 										Data:   "q1_1_1_option1",
-										Result: "BCD-267-1",
+										Trials: []string{"BCD-267-1"},
 									},
 									{
 										Text:   "2 и последующие линии терапии",
 										Data:   "q1_1_1_option2",
-										Result: "CL011101223 (Перьета Р-фарм)",
+										Trials: []string{"CL011101223"},
 									},
 								},
 							},
@@ -51,12 +51,12 @@ var Questions = []Question{
 						{
 							Text:   "1 линия",
 							Data:   "q2_1_option1",
-							Result: "CL01790199",
+							Trials: []string{"CL01790199"},
 						},
 						{
 							Text:   "2 линия",
 							Data:   "q2_1_option2",
-							Result: "Generium",
+							Trials: []string{"GNR-107"},
 						},
 					},
 				},
@@ -71,12 +71,12 @@ var Questions = []Question{
 						{
 							Text:   "EGFR, ALK neg. PD-L >= 50%",
 							Data:   "q3_1_option1",
-							Result: "MIT-002",
+							Trials: []string{"MIT-002"},
 						},
 						{
 							Text:   "EGFR, ALK neg. PD-L < 50%",
 							Data:   "q3_1_option2",
-							Result: "BEV-III/2022",
+							Trials: []string{"BEV-III/2022"},
 						},
 					},
 				},
@@ -84,17 +84,17 @@ var Questions = []Question{
 			{
 				Text:   "Меланома",
 				Data:   "q1_option4",
-				Result: "MIT-002",
+				Trials: []string{"MIT-002"},
 			},
 			{
 				Text:   "Рак головы и шеи",
 				Data:   "q1_option5",
-				Result: "р-фарм 2356",
+				Trials: []string{"RPH-002"},
 			},
 			{
 				Text:   "Рак желудка",
 				Data:   "q1_option6",
-				Result: "р-фарм 1339",
+				Trials: []string{"RB-012"},
 			},
 		},
 	},
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Rule Правило перехода по ответам, записанным в сессии.
+// Правила вопроса проверяются по порядку после ответа на него, первое подходящее
+// заменяет переход выбранного варианта. Если ни одно не подошло, работает обычный переход
+type Rule struct {
+	When   string `yaml:"when,omitempty"` // Условия через " and ", например "q1 = q1_option1 and q1_1 >= 2"
+	Option `yaml:",inline"`
+}
+
+// Condition Условие на ответ одного вопроса
+type Condition struct {
+	QuestionID string
+	Operator   string // =, !=, >, >=, <, <=
+	Value      string
+}
+
+// Answers Записанные ответы сессии по ID вопроса: data выбранных вариантов или введенное число
+type Answers map[string][]string
+
+var ErrInvalidCondition = errors.New("INVALID RULE CONDITION")
+
+var conditionPattern = regexp.MustCompile(`^([^\s=!<>]+)\s*(!=|>=|<=|=|>|<)\s*(\S+)$`)
+
+// ParseConditions разбирает условия правила
+func (r *Rule) ParseConditions() (conditions []Condition, err error) {
+	if strings.TrimSpace(r.When) == "" {
+		return nil, nil
+	}
+
+	for _, part := range strings.Split(r.When, " and ") {
+		match := conditionPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCondition, part)
+		}
+
+		condition := Condition{QuestionID: match[1], Operator: match[2], Value: match[3]}
+		if condition.IsNumeric() {
+			if _, err = strconv.ParseFloat(condition.Value, 64); err != nil {
+				return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidCondition, condition.Value)
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	return
+}
+
+// Matches проверяет, выполнены ли все условия правила. Правило без условий срабатывает всегда
+func (r *Rule) Matches(answers Answers) bool {
+	conditions, err := r.ParseConditions()
+	if err != nil {
+		return false
+	}
+
+	for _, condition := range conditions {
+		if !condition.Holds(answers) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNumeric сравнивает ли условие введенное число
+func (c Condition) IsNumeric() bool {
+	return c.Operator != "=" && c.Operator != "!="
+}
+
+// Holds проверяет условие. Условие на вопрос без ответа не выполняется
+func (c Condition) Holds(answers Answers) bool {
+	values, ok := answers[c.QuestionID]
+	if !ok {
+		return false
+	}
+
+	switch c.Operator {
+	case "=":
+		return slices.Contains(values, c.Value)
+	case "!=":
+		return !slices.Contains(values, c.Value)
+	}
+
+	limit, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return false
+	}
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		if c.compare(number, limit) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Condition) compare(value, limit float64) bool {
+	switch c.Operator {
+	case ">":
+		return value > limit
+	case ">=":
+		return value >= limit
+	case "<":
+		return value < limit
+	case "<=":
+		return value <= limit
+	}
+	return false
+}
+
+// MatchRule возвращает первое правило вопроса, условия которого выполнены для ответов сессии
+func (q *Question) MatchRule(answers Answers) *Rule {
+	for i := range q.Rules {
+		if q.Rules[i].Matches(answers) {
+			return &q.Rules[i]
+		}
+	}
+	return nil
+}
+
+// FormatAnswer Запись введенного числа в ответах сессии
+func FormatAnswer(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConditions(t *testing.T) {
+	rule := &Rule{When: "q1 = q1_option1 and q1_1>=2 and q2 != q2_option3"}
+	conditions, err := rule.ParseConditions()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []Condition{
+		{QuestionID: "q1", Operator: "=", Value: "q1_option1"},
+		{QuestionID: "q1_1", Operator: ">=", Value: "2"},
+		{QuestionID: "q2", Operator: "!=", Value: "q2_option3"},
+	}, conditions)
+
+	conditions, err = (&Rule{}).ParseConditions()
+	assert.NoError(t, err)
+	assert.Empty(t, conditions, "Правило без условий")
+
+	for _, when := range []string{"q1", "q1 = ", "q1_1 >= два", "q1 == a b"} {
+		_, err = (&Rule{When: when}).ParseConditions()
+		assert.ErrorIs(t, err, ErrInvalidCondition, when)
+	}
+}
+
+func TestMatchRule(t *testing.T) {
+	question := &Question{
+		Rules: []Rule{
+			{When: "nosology = lung and line >= 2", Option: Option{Data: "lung_second_line"}},
+			{When: "markers = egfr", Option: Option{Data: "egfr"}},
+			{When: "nosology != lung and line < 2", Option: Option{Data: "other_first_line"}},
+		},
+	}
+
+	for expected, answers := range map[string]Answers{
+		"lung_second_line": {"nosology": {"lung"}, "line": {"3"}},
+		"egfr":             {"nosology": {"lung"}, "line": {"1"}, "markers": {"alk", "egfr"}},
+		"other_first_line": {"nosology": {"melanoma"}, "line": {"1.5"}},
+	} {
+		rule := question.MatchRule(answers)
+		if assert.NotNil(t, rule, expected) {
+			assert.Equal(t, expected, rule.Data)
+		}
+	}
+
+	assert.Nil(t, question.MatchRule(Answers{"nosology": {"lung"}, "line": {"1"}}))
+	assert.Nil(t, question.MatchRule(Answers{"line": {"1"}}), "Условие на вопрос без ответа не выполняется")
+}
+
+func TestAnswersCleanedOnBack(t *testing.T) {
+	key := privateSession(905)
+
+	surveyService := GetInstance()
+	surveyService.Start(key)
+	defer surveyService.Reset(key)
+
+	first := surveyService.GetCurrentQuestion(key)
+	assert.NoError(t, surveyService.SaveAnswer(key, first.ID, "q1_option1"))
+	assert.NoError(t, surveyService.SaveQuestionToStack(key, first))
+	assert.Equal(t, Answers{first.ID: {"q1_option1"}}, surveyService.GetAnswers(key))
+
+	_, err := surveyService.PopFromQuestionStack(key)
+	assert.NoError(t, err)
+	assert.Empty(t, surveyService.GetAnswers(key), "Ответ на вопрос, к которому вернулись, забыт")
+}
+
+func TestLoadRules(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", `
+questions:
+  - id: q1
+    text: Укажите линию терапии
+    type: number
+    routes:
+      - {data: q1_any, trials: [MIT-002]}
+    rules:
+      - when: q1 >= 2
+        data: q1_second
+        trials: [BEV-III/2022]
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rule := survey.Questions[0].MatchRule(Answers{"q1": {FormatAnswer(2)}})
+	if assert.NotNil(t, rule) {
+		assert.Equal(t, []string{"BEV-III/2022"}, rule.Trials)
+	}
+	assert.Same(t, &survey.Questions[0].Rules[0].Option, survey.FindOption("q1_second"))
+}
@@ -0,0 +1,467 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChatUser Участник чата. В группе у каждого участника свои сессии, в личном чате ChatID совпадает с UserID
+type ChatUser struct {
+	ChatID int64 `yaml:"chat"`
+	UserID int64 `yaml:"user"`
+}
+
+// SessionKey Ключ сессии опроса: участник чата и сообщение, в котором идет опрос.
+// У каждого сообщения опроса своя сессия, поэтому опросы в разных темах форума не мешают друг другу.
+// Сессия с MessageID == 0 ждет отправки первого сообщения
+type SessionKey struct {
+	ChatUser  `yaml:",inline"`
+	MessageID int `yaml:"message"`
+}
+
+// messageRef Сообщение бота в чате
+type messageRef struct {
+	chatID    int64
+	messageID int
+}
+
+// Session Состояние опроса пользователя в хранилище сессий.
+// Вопросы хранятся по ID, а проверяемое исследование — по коду, поэтому сессию можно
+// сохранить на диск и продолжить после перезапуска бота
+type Session struct {
+	CurrentQuestion string              `yaml:"current_question,omitempty"`
+	QuestionStack   []string            `yaml:"question_stack,omitempty"`
+	Selections      map[string][]string `yaml:"selections,omitempty"` // отмеченные варианты вопросов с множественным выбором по ID вопроса
+	Answers         Answers             `yaml:"answers,omitempty"`    // ответы на пройденные вопросы для правил перехода
+	Checklist       *ChecklistSession   `yaml:"checklist,omitempty"`
+
+	// survey Версия опросника, на которой начат опрос. На диск не сохраняется:
+	// восстановленная после перезапуска сессия продолжается на актуальной версии
+	survey *Survey
+}
+
+// ChecklistSession Состояние проверки критериев исследования
+type ChecklistSession struct {
+	Trial   string            `yaml:"trial"`
+	Answers []CriterionAnswer `yaml:"answers,omitempty"`
+
+	trial *Trial // исследование той версии опросника, на которой начата проверка
+}
+
+// Survey возвращает версию опросника, на которой идет опрос
+func (s *Session) Survey() *Survey {
+	if s.survey != nil {
+		return s.survey
+	}
+	return CurrentSurvey()
+}
+
+// clone Копия сессии: хранилище не делит изменяемые данные с вызывающим кодом
+func (s *Session) clone() *Session {
+	session := *s
+	session.QuestionStack = slices.Clone(s.QuestionStack)
+	session.Selections = cloneAnswers(s.Selections)
+	session.Answers = cloneAnswers(s.Answers)
+	if s.Checklist != nil {
+		checklist := *s.Checklist
+		checklist.Answers = slices.Clone(s.Checklist.Answers)
+		session.Checklist = &checklist
+	}
+	return &session
+}
+
+func cloneAnswers(answers map[string][]string) map[string][]string {
+	if answers == nil {
+		return nil
+	}
+	cloned := make(map[string][]string, len(answers))
+	for questionID, values := range answers {
+		cloned[questionID] = slices.Clone(values)
+	}
+	return cloned
+}
+
+// SessionStore Хранилище сессий опроса, ID последних сообщений бота, владельцев сообщений и языков пользователей.
+// Load возвращает копию сессии, изменения сохраняются вызовом Save.
+// Save и SetLastMessageID отмечают активность участника чата и запоминают его владельцем сообщения.
+// Expire удаляет все данные участников, не активных с момента before, и возвращает их.
+// MigrateChat переносит данные группы в супергруппу, в которую она преобразована.
+// Выбранный язык — настройка пользователя, а не опроса: он не удаляется Expire
+type SessionStore interface {
+	Load(key SessionKey) (*Session, bool)
+	Save(key SessionKey, session *Session) error
+	Delete(key SessionKey) error
+	LastMessageID(user ChatUser) int
+	SetLastMessageID(user ChatUser, messageID int) error
+	MessageOwner(chatID int64, messageID int) int64
+	Expire(before time.Time) ([]ChatUser, error)
+	MigrateChat(from, to int64) error
+	Language(userID int64) string
+	SetLanguage(userID int64, lang string) error
+}
+
+// MemorySessionStore Хранилище сессий в памяти: сессии теряются при перезапуске бота
+type MemorySessionStore struct {
+	mu             sync.RWMutex
+	sessions       map[SessionKey]*Session
+	lastMessageIDs map[ChatUser]int
+	owners         map[messageRef]int64   // участник, для которого отправлено сообщение бота
+	activity       map[ChatUser]time.Time // время последней активности участника
+	languages      map[int64]string       // выбранный язык пользователя
+}
+
+// NewMemorySessionStore создает пустое хранилище сессий в памяти
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions:       map[SessionKey]*Session{},
+		lastMessageIDs: map[ChatUser]int{},
+		owners:         map[messageRef]int64{},
+		activity:       map[ChatUser]time.Time{},
+		languages:      map[int64]string{},
+	}
+}
+
+func (s *MemorySessionStore) Load(key SessionKey) (*Session, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[key]
+	if !ok {
+		return nil, false
+	}
+	return session.clone(), true
+}
+
+func (s *MemorySessionStore) Save(key SessionKey, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[key] = session.clone()
+	s.touch(key.ChatUser, key.MessageID)
+	return nil
+}
+
+func (s *MemorySessionStore) Delete(key SessionKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, key)
+	return nil
+}
+
+func (s *MemorySessionStore) LastMessageID(user ChatUser) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lastMessageIDs[user]
+}
+
+func (s *MemorySessionStore) SetLastMessageID(user ChatUser, messageID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastMessageIDs[user] = messageID
+	s.touch(user, messageID)
+	return nil
+}
+
+func (s *MemorySessionStore) MessageOwner(chatID int64, messageID int) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.owners[messageRef{chatID: chatID, messageID: messageID}]
+}
+
+// touch Отмечает активность участника и запоминает его владельцем сообщения
+func (s *MemorySessionStore) touch(user ChatUser, messageID int) {
+	s.activity[user] = time.Now()
+	if messageID != 0 {
+		s.owners[messageRef{chatID: user.ChatID, messageID: messageID}] = user.UserID
+	}
+}
+
+func (s *MemorySessionStore) Expire(before time.Time) (expired []ChatUser, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for user, active := range s.activity {
+		if active.Before(before) {
+			delete(s.lastMessageIDs, user)
+			delete(s.activity, user)
+			expired = append(expired, user)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+
+	for key := range s.sessions {
+		if _, ok := s.activity[key.ChatUser]; !ok {
+			delete(s.sessions, key)
+		}
+	}
+	for ref, userID := range s.owners {
+		if _, ok := s.activity[ChatUser{ChatID: ref.chatID, UserID: userID}]; !ok {
+			delete(s.owners, ref)
+		}
+	}
+	return
+}
+
+func (s *MemorySessionStore) MigrateChat(from, to int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, session := range s.sessions {
+		if key.ChatID == from {
+			delete(s.sessions, key)
+			key.ChatID = to
+			s.sessions[key] = session
+		}
+	}
+	for user, messageID := range s.lastMessageIDs {
+		if user.ChatID == from {
+			delete(s.lastMessageIDs, user)
+			s.lastMessageIDs[ChatUser{ChatID: to, UserID: user.UserID}] = messageID
+		}
+	}
+	for user, active := range s.activity {
+		if user.ChatID == from {
+			delete(s.activity, user)
+			s.activity[ChatUser{ChatID: to, UserID: user.UserID}] = active
+		}
+	}
+	for ref, userID := range s.owners {
+		if ref.chatID == from {
+			delete(s.owners, ref)
+			s.owners[messageRef{chatID: to, messageID: ref.messageID}] = userID
+		}
+	}
+	return nil
+}
+
+func (s *MemorySessionStore) Language(userID int64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.languages[userID]
+}
+
+func (s *MemorySessionStore) SetLanguage(userID int64, lang string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.languages[userID] = lang
+	return nil
+}
+
+// sessionFile Содержимое файла хранилища сессий
+type sessionFile struct {
+	Sessions  []sessionEntry  `yaml:"sessions,omitempty"`
+	Users     []userEntry     `yaml:"users,omitempty"`
+	Messages  []messageEntry  `yaml:"messages,omitempty"`
+	Languages []languageEntry `yaml:"languages,omitempty"`
+}
+
+type sessionEntry struct {
+	SessionKey `yaml:",inline"`
+	Session    *Session `yaml:"session"`
+}
+
+type userEntry struct {
+	ChatUser      `yaml:",inline"`
+	LastMessageID int       `yaml:"last_message,omitempty"`
+	Active        time.Time `yaml:"active"`
+}
+
+type messageEntry struct {
+	ChatID    int64 `yaml:"chat"`
+	MessageID int   `yaml:"message"`
+	UserID    int64 `yaml:"user"`
+}
+
+type languageEntry struct {
+	UserID int64  `yaml:"user"`
+	Lang   string `yaml:"lang"`
+}
+
+// FileSessionStore Хранилище сессий в YAML файле: сессии переживают перезапуск и обновление бота.
+// Сессии читаются и меняются в памяти, а в файл изменения записываются пачкой: Run периодически,
+// Flush при остановке бота. Поэтому нажатие кнопки не ждет записи файла.
+// При ошибке записи изменения остаются в памяти и записываются следующей попыткой
+type FileSessionStore struct {
+	*MemorySessionStore
+	path    string
+	dirty   atomic.Bool // есть изменения, не записанные в файл
+	writeMu sync.Mutex  // запись файла по очереди, чтобы последним записалось последнее состояние
+}
+
+// NewFileSessionStore создает хранилище и читает сохраненные сессии из файла path, если он существует
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	store := &FileSessionStore{MemorySessionStore: NewMemorySessionStore(), path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("READ SESSION FILE: %w", err)
+	}
+
+	var file sessionFile
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("PARSE SESSION FILE %s: %w", path, err)
+	}
+
+	for _, user := range file.Users {
+		if user.LastMessageID != 0 {
+			store.lastMessageIDs[user.ChatUser] = user.LastMessageID
+		}
+		store.activity[user.ChatUser] = user.Active
+	}
+	// Участники без отметки активности считаются активными с момента загрузки
+	now := time.Now()
+	for _, entry := range file.Sessions {
+		if entry.Session == nil {
+			continue
+		}
+		store.sessions[entry.SessionKey] = entry.Session
+		if _, ok := store.activity[entry.ChatUser]; !ok {
+			store.activity[entry.ChatUser] = now
+		}
+	}
+	for _, message := range file.Messages {
+		store.owners[messageRef{chatID: message.ChatID, messageID: message.MessageID}] = message.UserID
+	}
+	for _, language := range file.Languages {
+		store.languages[language.UserID] = language.Lang
+	}
+	return store, nil
+}
+
+func (s *FileSessionStore) Save(key SessionKey, session *Session) error {
+	_ = s.MemorySessionStore.Save(key, session)
+	s.dirty.Store(true)
+	return nil
+}
+
+func (s *FileSessionStore) Delete(key SessionKey) error {
+	_ = s.MemorySessionStore.Delete(key)
+	s.dirty.Store(true)
+	return nil
+}
+
+func (s *FileSessionStore) SetLastMessageID(user ChatUser, messageID int) error {
+	_ = s.MemorySessionStore.SetLastMessageID(user, messageID)
+	s.dirty.Store(true)
+	return nil
+}
+
+func (s *FileSessionStore) Expire(before time.Time) ([]ChatUser, error) {
+	expired, _ := s.MemorySessionStore.Expire(before)
+	if len(expired) > 0 {
+		s.dirty.Store(true)
+	}
+	return expired, nil
+}
+
+// Run раз в interval записывает накопившиеся изменения в файл. Работает до закрытия done,
+// после чего записывает последние изменения
+func (s *FileSessionStore) Run(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			if err := s.Flush(); err != nil {
+				log.Println("Error saving sessions:", err)
+			}
+			return
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				log.Println("Error saving sessions:", err)
+			}
+		}
+	}
+}
+
+func (s *FileSessionStore) MigrateChat(from, to int64) error {
+	_ = s.MemorySessionStore.MigrateChat(from, to)
+	s.dirty.Store(true)
+	return nil
+}
+
+func (s *FileSessionStore) SetLanguage(userID int64, lang string) error {
+	_ = s.MemorySessionStore.SetLanguage(userID, lang)
+	s.dirty.Store(true)
+	return nil
+}
+
+// Flush Записывает сессии в файл, если с прошлой записи были изменения. Файл заменяется целиком
+// через временный, чтобы при сбое во время записи не остался обрезанный файл
+func (s *FileSessionStore) Flush() (err error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if !s.dirty.Swap(false) {
+		return nil
+	}
+	defer func() {
+		if err != nil {
+			s.dirty.Store(true)
+		}
+	}()
+
+	data, err := yaml.Marshal(s.snapshot())
+	if err != nil {
+		return fmt.Errorf("MARSHAL SESSIONS: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("WRITE SESSION FILE: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("WRITE SESSION FILE: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("WRITE SESSION FILE: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("WRITE SESSION FILE: %w", err)
+	}
+	return nil
+}
+
+// snapshot Содержимое файла для текущего состояния хранилища
+func (s *FileSessionStore) snapshot() (file sessionFile) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for key, session := range s.sessions {
+		file.Sessions = append(file.Sessions, sessionEntry{SessionKey: key, Session: session})
+	}
+	for user, active := range s.activity {
+		file.Users = append(file.Users, userEntry{ChatUser: user, LastMessageID: s.lastMessageIDs[user], Active: active})
+	}
+	for ref, userID := range s.owners {
+		file.Messages = append(file.Messages, messageEntry{ChatID: ref.chatID, MessageID: ref.messageID, UserID: userID})
+	}
+	for userID, lang := range s.languages {
+		file.Languages = append(file.Languages, languageEntry{UserID: userID, Lang: lang})
+	}
+	return
+}
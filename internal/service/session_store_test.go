@@ -0,0 +1,248 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// privateSession Ключ сессии в личном чате пользователя, ожидающей первого сообщения
+func privateSession(userID int64) SessionKey {
+	return SessionKey{ChatUser: ChatUser{ChatID: userID, UserID: userID}}
+}
+
+func TestFileSessionStoreSurvivesRestart(t *testing.T) {
+	key := privateSession(906)
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+
+	store, err := NewFileSessionStore(path)
+	if !assert.NoError(t, err, "Файла сессий еще нет") {
+		return
+	}
+	surveyService := newSurveyService(store)
+
+	surveyService.Start(key)
+	first := surveyService.GetCurrentQuestion(key)
+	next := first.Options[0].NextQuestion
+	assert.NoError(t, surveyService.SaveAnswer(key, first.ID, first.Options[0].Data))
+	assert.NoError(t, surveyService.SaveQuestionToStack(key, first))
+	assert.NoError(t, surveyService.SetCurrentQuestion(key, next))
+	assert.NoError(t, surveyService.ToggleSelection(key, next.ID, "q1_1_option1"))
+	surveyService.StartChecklist(key, CurrentSurvey().GetTrial("AREAL"))
+	assert.NoError(t, surveyService.SaveChecklistAnswer(key, AnswerYes))
+	surveyService.SetLastMessageID(key.ChatUser, 42)
+	surveyService.SetLanguage(key.UserID, "en")
+	assert.NoError(t, store.Flush())
+
+	// Перезапуск: новое хранилище читает сессии из того же файла
+	store, err = NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	surveyService = newSurveyService(store)
+
+	assert.Equal(t, next, surveyService.GetCurrentQuestion(key), "Вопрос восстановлен по ID")
+	assert.Equal(t, []*Question{first}, surveyService.GetQuestionsStack(key))
+	assert.Equal(t, Answers{first.ID: {first.Options[0].Data}}, surveyService.GetAnswers(key))
+	assert.Equal(t, []string{"q1_1_option1"}, surveyService.GetSelection(key, next.ID))
+	assert.Equal(t, 42, surveyService.GetLastMessageID(key.ChatUser))
+	assert.Equal(t, "en", surveyService.GetLanguage(key.UserID), "Выбранный язык восстановлен")
+
+	trial, answers := surveyService.GetChecklist(key)
+	assert.Equal(t, "AREAL", trial.Code, "Исследование восстановлено по коду")
+	assert.Equal(t, []CriterionAnswer{AnswerYes}, answers)
+
+	prev, err := surveyService.PopFromQuestionStack(key)
+	assert.NoError(t, err)
+	assert.Equal(t, first, prev)
+
+	surveyService.Reset(key)
+	assert.NoError(t, store.Flush())
+	store, _ = NewFileSessionStore(path)
+	_, ok := store.Load(key)
+	assert.False(t, ok, "Завершенная сессия удалена из файла")
+	assert.Equal(t, 42, store.LastMessageID(key.ChatUser))
+}
+
+func TestFileSessionStoreErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	if err := os.WriteFile(path, []byte("sessions: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewFileSessionStore(path)
+	assert.Error(t, err, "Поврежденный файл сессий")
+
+	store, err := NewFileSessionStore(filepath.Join(t.TempDir(), "missing", "sessions.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Save(privateSession(1), &Session{CurrentQuestion: "q1"}), "Сохранение не ждет записи файла")
+	assert.Error(t, store.Flush(), "Каталога нет, файл не записать")
+	_, ok := store.Load(privateSession(1))
+	assert.True(t, ok, "Сессия осталась в памяти")
+	assert.Error(t, store.Flush(), "Незаписанные изменения записываются повторно")
+}
+
+func TestFileSessionStoreRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	store, err := NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Flush(), "Без изменений файл не записывается")
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		store.Run(time.Hour, done)
+		close(stopped)
+	}()
+	assert.NoError(t, store.Save(privateSession(1), &Session{CurrentQuestion: "q1"}))
+	close(done)
+	<-stopped
+
+	// При остановке записываются последние изменения
+	store, err = NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, ok := store.Load(privateSession(1))
+	assert.True(t, ok)
+}
+
+func TestMemorySessionStoreCopies(t *testing.T) {
+	key := privateSession(1)
+	store := NewMemorySessionStore()
+	assert.NoError(t, store.Save(key, &Session{CurrentQuestion: "q1", Answers: Answers{"q1": {"a"}}}))
+
+	session, ok := store.Load(key)
+	if !assert.True(t, ok) {
+		return
+	}
+	session.CurrentQuestion = "q2"
+	session.Answers["q1"][0] = "b"
+
+	session, _ = store.Load(key)
+	assert.Equal(t, "q1", session.CurrentQuestion, "Изменения вступают в силу только после Save")
+	assert.Equal(t, Answers{"q1": {"a"}}, session.Answers)
+
+	assert.NoError(t, store.Delete(key))
+	_, ok = store.Load(key)
+	assert.False(t, ok)
+}
+
+func TestSweepSessions(t *testing.T) {
+	key, active := privateSession(907), privateSession(908)
+
+	surveyService := newSurveyService(NewMemorySessionStore())
+	surveyService.Start(key)
+	surveyService.SetLastMessageID(key.ChatUser, 10)
+	surveyService.SetLocationRequest(key.ChatUser, "AREAL")
+	surveyService.SetLanguage(key.UserID, "en")
+
+	assert.Zero(t, surveyService.ExpireSessions(time.Hour), "Сессия еще активна")
+	assert.NotNil(t, surveyService.GetCurrentQuestion(key))
+
+	done := make(chan struct{})
+	defer close(done)
+	go surveyService.SweepSessions(200*time.Millisecond, 10*time.Millisecond, done)
+
+	// Активность продлевает сессию, брошенная удаляется
+	surveyService.Start(active)
+	for i := 0; i < 6; i++ {
+		time.Sleep(50 * time.Millisecond)
+		surveyService.SetLastMessageID(active.ChatUser, i)
+	}
+	assert.Nil(t, surveyService.GetCurrentQuestion(key))
+	assert.Zero(t, surveyService.GetLastMessageID(key.ChatUser), "ID последнего сообщения удален вместе с сессией")
+	assert.Zero(t, surveyService.GetMessageOwner(key.ChatID, 10), "Владелец сообщения забыт")
+	assert.Empty(t, surveyService.GetLocationRequest(key.ChatUser))
+	assert.Equal(t, "en", surveyService.GetLanguage(key.UserID), "Выбранный язык сохраняется")
+	assert.NotNil(t, surveyService.GetCurrentQuestion(active))
+}
+
+func TestFileSessionStoreExpire(t *testing.T) {
+	first, second := privateSession(1), privateSession(2)
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	store, err := NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, store.Save(first, &Session{CurrentQuestion: "q1"}))
+	assert.NoError(t, store.SetLastMessageID(second.ChatUser, 20))
+	assert.NoError(t, store.Flush())
+	saved := time.Now()
+
+	// Время активности сохраняется в файле, а не отсчитывается заново от загрузки
+	store, err = NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	expired, err := store.Expire(saved.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+
+	expired, err = store.Expire(saved)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []ChatUser{first.ChatUser, second.ChatUser}, expired)
+	assert.NoError(t, store.Flush())
+
+	store, _ = NewFileSessionStore(path)
+	_, ok := store.Load(first)
+	assert.False(t, ok, "Удаленная сессия не вернулась после перезапуска")
+	assert.Zero(t, store.LastMessageID(second.ChatUser))
+}
+
+func TestGroupSessions(t *testing.T) {
+	const groupID, supergroupID = -100, -1001
+	alice := SessionKey{ChatUser: ChatUser{ChatID: groupID, UserID: 11}, MessageID: 5}
+	bob := SessionKey{ChatUser: ChatUser{ChatID: groupID, UserID: 12}, MessageID: 6}
+
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	store, err := NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	surveyService := newSurveyService(store)
+
+	// У каждого участника группы своя сессия, сообщение опроса принадлежит начавшему его
+	surveyService.Start(alice)
+	surveyService.Start(bob)
+	first := surveyService.GetCurrentQuestion(alice)
+	assert.NoError(t, surveyService.SetCurrentQuestion(alice, first.Options[0].NextQuestion))
+	assert.Equal(t, first, surveyService.GetCurrentQuestion(bob), "Ответ одного участника не меняет сессию другого")
+	assert.Equal(t, int64(11), surveyService.GetMessageOwner(groupID, 5))
+	assert.Equal(t, int64(12), surveyService.GetMessageOwner(groupID, 6))
+	assert.Zero(t, surveyService.GetMessageOwner(groupID, 7), "Владелец неизвестен")
+
+	// Продолжение опроса новым сообщением
+	moved := SessionKey{ChatUser: alice.ChatUser, MessageID: 8}
+	surveyService.MoveSession(alice, moved)
+	assert.Nil(t, surveyService.GetCurrentQuestion(alice))
+	assert.Equal(t, first.Options[0].NextQuestion, surveyService.GetCurrentQuestion(moved))
+	assert.Equal(t, int64(11), surveyService.GetMessageOwner(groupID, 8))
+
+	// Группа преобразована в супергруппу: сессии и владельцы переносятся и сохраняются в файле
+	surveyService.SetLocationRequest(bob.ChatUser, "AREAL")
+	surveyService.MigrateChat(groupID, supergroupID)
+	assert.Empty(t, surveyService.GetLocationRequest(bob.ChatUser))
+	assert.Equal(t, "AREAL", surveyService.GetLocationRequest(ChatUser{ChatID: supergroupID, UserID: 12}))
+	assert.NoError(t, store.Flush())
+
+	store, err = NewFileSessionStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	restored := newSurveyService(store)
+
+	migrated := SessionKey{ChatUser: ChatUser{ChatID: supergroupID, UserID: 12}, MessageID: 6}
+	assert.Nil(t, restored.GetCurrentQuestion(bob))
+	assert.Equal(t, first, restored.GetCurrentQuestion(migrated))
+	assert.Equal(t, int64(11), restored.GetMessageOwner(supergroupID, 8))
+	assert.Zero(t, restored.GetMessageOwner(groupID, 8))
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Survey Содержимое опросника: дерево вопросов и реестр исследований.
+// Опрос начинается с первого вопроса, остальные вопросы верхнего уровня — общие,
+// на них ссылаются варианты ответа по ID (next).
+// Реестр исследований может лежать в отдельном файле (trials_file), который обновляет импорт из таблицы
+type Survey struct {
+	Questions  []Question `yaml:"questions"`
+	Trials     []Trial    `yaml:"trials"`
+	TrialsFile string     `yaml:"trials_file,omitempty"` // Путь к реестру относительно файла опросника
+}
+
+// Nosologies возвращает нозологии — варианты ответа на первый вопрос опросника
+func (s *Survey) Nosologies() (nosologies []string) {
+	if len(s.Questions) == 0 {
+		return nil
+	}
+	for _, option := range s.Questions[0].Options {
+		nosologies = append(nosologies, option.Text)
+	}
+	return
+}
+
+// GetTrial возвращает исследование из реестра по коду
+func (s *Survey) GetTrial(code string) *Trial {
+	for i := range s.Trials {
+		if s.Trials[i].Code == code {
+			return &s.Trials[i]
+		}
+	}
+	return nil
+}
+
+// FindQuestion ищет вопрос по ID во всем дереве вопросов.
+// ID вопросов в загруженном опроснике уникальны, по ним сессия хранит текущий вопрос и историю
+func (s *Survey) FindQuestion(id string) *Question {
+	if id == "" {
+		return nil
+	}
+
+	var find func(question *Question) *Question
+	find = func(question *Question) *Question {
+		if question.ID == id {
+			return question
+		}
+		for _, option := range question.Targets() {
+			if option.NextQuestion != nil {
+				if found := find(option.NextQuestion); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	}
+
+	for i := range s.Questions {
+		if found := find(&s.Questions[i]); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// FindOption ищет вариант ответа или правило перехода по data во всем дереве вопросов
+func (s *Survey) FindOption(data string) *Option {
+	var find func(question *Question) *Option
+	find = func(question *Question) *Option {
+		for _, option := range question.Targets() {
+			if option.Matches(data) {
+				return option
+			}
+			if option.NextQuestion != nil {
+				if found := find(option.NextQuestion); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	}
+
+	for i := range s.Questions {
+		if found := find(&s.Questions[i]); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// IsOptionAvailable проверяет, ведет ли вариант ответа хотя бы к одному незакрытому исследованию.
+// Варианты, ведущие только к закрытым исследованиям, не показываются пользователю
+func (s *Survey) IsOptionAvailable(option *Option, now time.Time) bool {
+	for _, code := range option.Trials {
+		if trial := s.GetTrial(code); trial != nil && !trial.IsClosed(now) {
+			return true
+		}
+	}
+
+	if next := option.GetNextQuestion(); next != nil {
+		for _, target := range next.Targets() {
+			if s.IsOptionAvailable(target, now) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LoadSurvey читает опросник из YAML или JSON файла (JSON является подмножеством YAML)
+func LoadSurvey(path string) (survey *Survey, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("READ SURVEY FILE: %w", err)
+	}
+
+	survey = &Survey{}
+	if err = yaml.Unmarshal(data, survey); err != nil {
+		return nil, fmt.Errorf("PARSE SURVEY FILE %s: %w", path, err)
+	}
+
+	if len(survey.Questions) == 0 {
+		return nil, errors.New("SURVEY FILE HAS NO QUESTIONS")
+	}
+
+	resolveDocuments(survey.Trials, filepath.Dir(path))
+	if survey.TrialsFile != "" {
+		// Реестра еще нет до первого импорта: исследования берутся только из опросника
+		registryPath := survey.RegistryPath(path)
+		registry, err := LoadTrials(registryPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		resolveDocuments(registry, filepath.Dir(registryPath))
+		survey.Trials = MergeRegistry(survey.Trials, registry)
+	}
+
+	if err = survey.resolveReferences(); err != nil {
+		return nil, fmt.Errorf("RESOLVE SURVEY FILE %s: %w", path, err)
+	}
+	return
+}
+
+// resolveReferences подставляет вопросы, на которые варианты ответа ссылаются по ID,
+// превращая дерево в граф без циклов. ID каждого вопроса должен быть задан и уникален:
+// по нему сессия восстанавливает вопрос
+func (s *Survey) resolveReferences() error {
+	var (
+		byID      = map[string][]*Question{}
+		questions []*Question
+		index     func(question *Question)
+	)
+
+	index = func(question *Question) {
+		questions = append(questions, question)
+		byID[question.ID] = append(byID[question.ID], question)
+		for _, option := range question.Targets() {
+			if option.NextQuestion != nil {
+				index(option.NextQuestion)
+			}
+		}
+	}
+	for i := range s.Questions {
+		index(&s.Questions[i])
+	}
+
+	for _, question := range questions {
+		switch {
+		case question.ID == "":
+			return fmt.Errorf("QUESTION %q HAS NO ID", question.Text)
+		case len(byID[question.ID]) > 1:
+			return fmt.Errorf("QUESTION ID %s IS NOT UNIQUE", question.ID)
+		}
+	}
+
+	for _, question := range questions {
+		for _, option := range question.Targets() {
+			if option.Next == "" {
+				continue
+			}
+
+			targets := byID[option.Next]
+			switch {
+			case option.NextQuestion != nil:
+				return fmt.Errorf("OPTION %s HAS BOTH next AND next_question", option.Data)
+			case len(targets) == 0:
+				return fmt.Errorf("UNKNOWN QUESTION %s REFERENCED BY OPTION %s", option.Next, option.Data)
+			}
+			option.NextQuestion = targets[0]
+		}
+	}
+
+	return checkCycles(questions)
+}
+
+// checkCycles проверяет, что переходы между вопросами не образуют цикл
+func checkCycles(questions []*Question) error {
+	const (
+		inProgress = 1
+		done       = 2
+	)
+	state := map[*Question]int{}
+
+	var visit func(question *Question) error
+	visit = func(question *Question) error {
+		switch state[question] {
+		case inProgress:
+			return fmt.Errorf("QUESTION %s IS PART OF A CYCLE", question.ID)
+		case done:
+			return nil
+		}
+
+		state[question] = inProgress
+		for _, option := range question.Targets() {
+			if option.NextQuestion != nil {
+				if err := visit(option.NextQuestion); err != nil {
+					return err
+				}
+			}
+		}
+		state[question] = done
+		return nil
+	}
+
+	for _, question := range questions {
+		if err := visit(question); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultSurvey возвращает встроенный в бинарник опросник
+func DefaultSurvey() *Survey {
+	return &Survey{
+		Questions: Questions,
+		Trials:    Trials,
+	}
+}
+
+// currentSurvey Актуальная версия опросника, которую получают новые сессии
+var currentSurvey atomic.Pointer[Survey]
+
+// CurrentSurvey возвращает актуальную версию опросника
+func CurrentSurvey() *Survey {
+	if survey := currentSurvey.Load(); survey != nil {
+		return survey
+	}
+	return DefaultSurvey()
+}
+
+// UseSurvey атомарно подменяет актуальный опросник.
+// Уже начатые сессии доходят до конца на той версии, с которой начинали
+func UseSurvey(survey *Survey) {
+	currentSurvey.Store(survey)
+}
+
+// ReloadSurvey перечитывает опросник из файла и подменяет актуальный.
+// Структурные ошибки не блокируют загрузку и возвращаются для логирования
+func ReloadSurvey(path string) (problems []Problem, err error) {
+	if path == "" {
+		return nil, errors.New("SURVEY FILE IS NOT CONFIGURED")
+	}
+
+	survey, err := LoadSurvey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	UseSurvey(survey)
+	return survey.Validate(), nil
+}
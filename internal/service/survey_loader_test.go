@@ -0,0 +1,262 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const surveyYAML = `
+questions:
+  - id: q1
+    text: Выберите нозологию
+    options:
+      - text: Рак легкого
+        data: q1_option1
+        next_question:
+          id: q1_1
+          text: Выберите профиль
+          options:
+            - text: PD-L1 >= 50%
+              data: q1_1_option1
+              trials: [MIT-002]
+      - text: Меланома
+        data: q1_option2
+        trials: [MIT-002]
+trials:
+  - code: MIT-002
+    title: Исследование препарата MIT-002
+    inclusion:
+      - НМРЛ или меланома
+    exclusion:
+      - Метастазы в ЦНС
+`
+
+const surveyJSON = `{
+  "questions": [
+    {"id": "q1", "text": "Выберите нозологию", "options": [
+      {"text": "Меланома", "data": "q1_option1", "trials": ["MIT-002"]}
+    ]}
+  ],
+  "trials": [
+    {"code": "MIT-002", "title": "Исследование MIT-002", "inclusion": ["Меланома"], "exclusion": []}
+  ]
+}`
+
+func writeSurveyFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSurveyYAML(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", surveyYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, survey.Questions, 1)
+	root := survey.Questions[0]
+	assert.Equal(t, "q1", root.ID)
+	if !assert.Len(t, root.Options, 2) {
+		return
+	}
+
+	next := root.Options[0].GetNextQuestion()
+	if !assert.NotNil(t, next) {
+		return
+	}
+	assert.Equal(t, "q1_1", next.ID)
+	assert.True(t, next.Options[0].IsTerminal())
+	assert.Equal(t, []string{"MIT-002"}, next.Options[0].Trials)
+	assert.Equal(t, []string{"MIT-002"}, root.Options[1].Trials, "Одно исследование доступно из двух веток")
+	assert.Same(t, &root.Options[1], survey.FindOption("q1_option2"))
+	assert.Same(t, &next.Options[0], survey.FindOption("q1_1_option1"))
+
+	trial := survey.GetTrial("MIT-002")
+	if !assert.NotNil(t, trial) {
+		return
+	}
+	assert.Equal(t, []string{"НМРЛ или меланома"}, trial.Inclusion)
+	assert.Nil(t, survey.GetTrial("UNKNOWN"))
+}
+
+func TestLoadSurveyJSON(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.json", surveyJSON))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Выберите нозологию", survey.Questions[0].Text)
+	assert.Equal(t, "Исследование MIT-002", survey.GetTrial("MIT-002").Title)
+}
+
+func TestLoadSurveyErrors(t *testing.T) {
+	_, err := LoadSurvey(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err, "Отсутствующий файл")
+
+	_, err = LoadSurvey(writeSurveyFile(t, "empty.yaml", "trials: []\n"))
+	assert.Error(t, err, "Файл без вопросов")
+
+	_, err = LoadSurvey(writeSurveyFile(t, "broken.yaml", "questions: [\n"))
+	assert.Error(t, err, "Некорректный YAML")
+}
+
+func TestReloadKeepsStartedSessions(t *testing.T) {
+	key, newKey := privateSession(901), privateSession(902)
+
+	surveyService := GetInstance()
+	surveyService.Start(key)
+	oldQuestion := surveyService.GetCurrentQuestion(key)
+
+	problems, err := ReloadSurvey(writeSurveyFile(t, "survey.yaml", surveyYAML))
+	defer UseSurvey(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, problems)
+
+	// Начатая сессия доходит до конца на старой версии
+	assert.Same(t, oldQuestion, surveyService.GetCurrentQuestion(key))
+	assert.Equal(t, Trials, surveyService.GetSurvey(key).Trials)
+
+	// Новая сессия получает новую версию
+	surveyService.Start(newKey)
+	assert.Equal(t, "Рак легкого", surveyService.GetCurrentQuestion(newKey).Options[0].Text)
+	assert.Equal(t, "Исследование препарата MIT-002", surveyService.GetSurvey(newKey).GetTrial("MIT-002").Title)
+
+	_, err = ReloadSurvey("")
+	assert.Error(t, err, "Файл опросника не задан")
+
+	surveyService.Reset(key)
+	surveyService.Reset(newKey)
+}
+
+const sharedSurveyYAML = `
+questions:
+  - id: q1
+    text: Выберите нозологию
+    options:
+      - {text: Рак легкого, data: q1_lung, next: line}
+      - {text: Рак желудка, data: q1_gastric, next: line}
+  - id: line
+    text: Укажите линию терапии
+    options:
+      - {text: 1 линия, data: line_first, trials: [MIT-002]}
+      - {text: 2 линия, data: line_second, trials: [MIT-002]}
+trials:
+  - code: MIT-002
+    title: Исследование MIT-002
+    inclusion: [НМРЛ]
+`
+
+func TestLoadSharedQuestions(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", sharedSurveyYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	root := survey.Questions[0]
+	shared := &survey.Questions[1]
+	assert.Same(t, shared, root.Options[0].GetNextQuestion(), "Ссылка разрешена в общий вопрос")
+	assert.Same(t, shared, root.Options[1].GetNextQuestion(), "Обе ветки ведут в один вопрос")
+	assert.Same(t, &shared.Options[1], survey.FindOption("line_second"))
+	assert.Empty(t, survey.Validate())
+}
+
+func TestFindQuestion(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", sharedSurveyYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Same(t, &survey.Questions[0], survey.FindQuestion("q1"))
+	assert.Same(t, &survey.Questions[1], survey.FindQuestion("line"))
+	assert.Same(t, DefaultSurvey().Questions[0].Options[0].NextQuestion, DefaultSurvey().FindQuestion("q1_1"), "Вложенный вопрос")
+	assert.Nil(t, survey.FindQuestion("missing"))
+	assert.Nil(t, survey.FindQuestion(""))
+}
+
+func TestLoadReferenceErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown": `
+questions:
+  - id: q1
+    text: Вопрос
+    options: [{text: Да, data: q1_yes, next: missing}]
+`,
+		"cycle": `
+questions:
+  - id: q1
+    text: Вопрос
+    options: [{text: Да, data: q1_yes, next: q2}]
+  - id: q2
+    text: Вопрос
+    options: [{text: Да, data: q2_yes, next: q1}]
+`,
+		"both": `
+questions:
+  - id: q1
+    text: Вопрос
+    options:
+      - text: Да
+        data: q1_yes
+        next: q2
+        next_question: {id: q1_1, text: Вопрос, options: [{text: Да, data: q1_1_yes, trials: [A]}]}
+  - id: q2
+    text: Вопрос
+    options: [{text: Да, data: q2_yes, trials: [A]}]
+`,
+		"duplicate": `
+questions:
+  - id: q1
+    text: Вопрос
+    options: [{text: Да, data: q1_yes, next: q2}]
+  - id: q2
+    text: Вопрос
+    options: [{text: Да, data: q2_yes, trials: [A]}]
+  - id: q2
+    text: Вопрос
+    options: [{text: Да, data: q3_yes, trials: [A]}]
+`,
+		"no id": `
+questions:
+  - text: Вопрос
+    options: [{text: Да, data: q1_yes, trials: [A]}]
+`,
+		"nested duplicate": `
+questions:
+  - id: q1
+    text: Вопрос
+    options:
+      - text: А
+        data: q1_a
+        next_question: {id: line, text: Линия А, options: [{text: Да, data: a_yes, trials: [A]}]}
+      - text: Б
+        data: q1_b
+        next_question: {id: line, text: Линия Б, options: [{text: Да, data: b_yes, trials: [A]}]}
+`,
+	} {
+		_, err := LoadSurvey(writeSurveyFile(t, name+".yaml", content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestValidateSharedQuestions(t *testing.T) {
+	survey := &Survey{
+		Questions: []Question{
+			{ID: "q1", Text: "Вопрос", Options: []Option{{Text: "Да", Data: "q1_yes", Trials: []string{"A"}}}},
+			{ID: "q1", Text: "Вопрос", Options: []Option{{Text: "Да", Data: "q2_yes", Trials: []string{"A"}}}},
+		},
+		Trials: []Trial{{Code: "A", Title: "Исследование A", Inclusion: []string{"Критерий"}}},
+	}
+
+	assert.Equal(t, []Problem{
+		{Location: "q1", Message: "общий вопрос не используется ни одним вариантом ответа"},
+		{Location: "q1", Message: "ID вопроса повторяется, ссылки next и условия правил неоднозначны"},
+	}, survey.Validate())
+}
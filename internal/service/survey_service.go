@@ -2,122 +2,419 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"slices"
 	"sync"
+	"time"
 )
 
 // SurveyService Структура синглтон для работы с опросником
 type SurveyService struct {
-	mu               sync.RWMutex
-	userAnswersMap   map[int64]*userAnswers
-	lastMessageIDMap map[int64]int
+	mu          sync.RWMutex
+	store       SessionStore        // сессии опроса, ID последних сообщений, владельцы сообщений и языки пользователей
+	locationMap map[ChatUser]string // код исследования, для которого участник чата ищет ближайший центр
 }
 
-// userAnswers Структура для хранения ответов пользователя
-type userAnswers struct {
-	currentQuestion *Question
-	questionStack   []*Question
+func newSurveyService(store SessionStore) *SurveyService {
+	return &SurveyService{
+		store:       store,
+		locationMap: make(map[ChatUser]string),
+	}
+}
+
+// UseStore подменяет хранилище сессий. Вызывается при запуске бота до обработки обновлений
+func (s *SurveyService) UseStore(store SessionStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store = store
 }
 
-func (s *SurveyService) Start(userID int64) {
+// save Записывает сессию в хранилище. Ошибка записи не прерывает опрос и только логируется
+func (s *SurveyService) save(key SessionKey, session *Session) {
+	if err := s.store.Save(key, session); err != nil {
+		log.Println("Error saving session:", err)
+	}
+}
+
+// Start начинает опрос с первого вопроса актуальной версии опросника
+func (s *SurveyService) Start(key SessionKey) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.userAnswersMap[userID] = &userAnswers{
-		currentQuestion: &Questions[0],
-		questionStack:   []*Question{},
+	survey := CurrentSurvey()
+	s.save(key, &Session{
+		CurrentQuestion: survey.Questions[0].ID,
+		survey:          survey,
+	})
+}
+
+// GetSurvey возвращает версию опросника, на которой идет опрос пользователя
+func (s *SurveyService) GetSurvey(key SessionKey) *Survey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if session, ok := s.store.Load(key); ok {
+		return session.Survey()
 	}
+	return CurrentSurvey()
 }
 
-func (s *SurveyService) Reset(userID int64) {
+func (s *SurveyService) Reset(key SessionKey) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.userAnswersMap, userID)
+	if err := s.store.Delete(key); err != nil {
+		log.Println("Error deleting session:", err)
+	}
 }
 
-func (s *SurveyService) PopFromQuestionStack(userID int64) (prevQuestion *Question, err error) {
+// MoveSession переносит сессию в другое сообщение, когда опрос продолжается новым сообщением
+func (s *SurveyService) MoveSession(from, to SessionKey) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	userAnswersMap, ok := s.userAnswersMap[userID]
+	session, ok := s.store.Load(from)
+	if !ok || from == to {
+		return
+	}
+	if err := s.store.Delete(from); err != nil {
+		log.Println("Error deleting session:", err)
+	}
+	s.save(to, session)
+}
+
+func (s *SurveyService) PopFromQuestionStack(key SessionKey) (prevQuestion *Question, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.Load(key)
 	if !ok {
 		err = errors.New("USER STATE NOT FOUND IN MAP")
 		return
 	}
 
-	stackLen := len(userAnswersMap.questionStack)
+	stackLen := len(session.QuestionStack)
 	if stackLen == 0 {
 		err = errors.New("QUESTION STACK IS EMPTY")
 		return
 	}
 
-	prevQuestion = userAnswersMap.questionStack[stackLen-1]
-	s.userAnswersMap[userID].questionStack = userAnswersMap.questionStack[:stackLen-1]
+	prevID := session.QuestionStack[stackLen-1]
+	session.QuestionStack = session.QuestionStack[:stackLen-1]
+	// Пользователь ответит на вопрос заново, старый ответ не должен влиять на правила
+	delete(session.Answers, prevID)
+	s.save(key, session)
 
+	if prevQuestion = session.Survey().FindQuestion(prevID); prevQuestion == nil {
+		err = fmt.Errorf("QUESTION %s NOT FOUND IN SURVEY", prevID)
+	}
 	return
 }
 
-func (s *SurveyService) GetQuestionsStack(userID int64) (stack []*Question) {
+func (s *SurveyService) GetQuestionsStack(key SessionKey) (stack []*Question) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if mapByID, ok := s.userAnswersMap[userID]; ok {
-		stack = mapByID.questionStack
+	if session, ok := s.store.Load(key); ok {
+		survey := session.Survey()
+		for _, id := range session.QuestionStack {
+			if question := survey.FindQuestion(id); question != nil {
+				stack = append(stack, question)
+			}
+		}
 	}
 	return
 }
 
-func (s *SurveyService) SaveQuestionToStack(userID int64, question *Question) (err error) {
+func (s *SurveyService) SaveQuestionToStack(key SessionKey, question *Question) (err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.userAnswersMap[userID]; !ok {
+	session, ok := s.store.Load(key)
+	if !ok {
 		err = errors.New("USER STATE NOT FOUND IN MAP")
 		return
 	}
 
-	s.userAnswersMap[userID].questionStack = append(s.userAnswersMap[userID].questionStack, question)
+	session.QuestionStack = append(session.QuestionStack, question.ID)
+	s.save(key, session)
 	return
 }
 
-func (s *SurveyService) GetCurrentQuestion(userID int64) (question *Question) {
+func (s *SurveyService) GetCurrentQuestion(key SessionKey) (question *Question) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if mapByID, ok := s.userAnswersMap[userID]; ok {
-		question = mapByID.currentQuestion
+	if session, ok := s.store.Load(key); ok {
+		question = session.Survey().FindQuestion(session.CurrentQuestion)
 	}
 	return
 }
 
-func (s *SurveyService) SetCurrentQuestion(userID int64, question *Question) (err error) {
+func (s *SurveyService) SetCurrentQuestion(key SessionKey, question *Question) (err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.userAnswersMap[userID]; !ok {
+	session, ok := s.store.Load(key)
+	if !ok {
 		err = errors.New("USER STATE NOT FOUND IN MAP")
 		return
 	}
 
-	s.userAnswersMap[userID].currentQuestion = question
+	session.CurrentQuestion = ""
+	if question != nil {
+		session.CurrentQuestion = question.ID
+	}
+	s.save(key, session)
 	return
 }
 
-func (s *SurveyService) GetLastMessageID(userID int64) (lastMessageID int) {
+// GetLastMessageID возвращает ID последнего сообщения бота, отправленного для участника чата
+func (s *SurveyService) GetLastMessageID(user ChatUser) (lastMessageID int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if mapByUserID, ok := s.lastMessageIDMap[userID]; ok {
-		lastMessageID = mapByUserID
+	return s.store.LastMessageID(user)
+}
+
+// SetLastMessageID запоминает последнее сообщение бота для участника чата, участник становится его владельцем
+func (s *SurveyService) SetLastMessageID(user ChatUser, lastMessageID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.SetLastMessageID(user, lastMessageID); err != nil {
+		log.Println("Error saving last message ID:", err)
+	}
+}
+
+// GetMessageOwner возвращает ID пользователя, для которого отправлено сообщение бота, или 0, если он неизвестен
+func (s *SurveyService) GetMessageOwner(chatID int64, messageID int) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.store.MessageOwner(chatID, messageID)
+}
+
+// MigrateChat переносит сессии и запросы местоположения группы в супергруппу, в которую она преобразована
+func (s *SurveyService) MigrateChat(from, to int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.MigrateChat(from, to); err != nil {
+		log.Println("Error migrating sessions:", err)
+	}
+	for user, code := range s.locationMap {
+		if user.ChatID == from {
+			delete(s.locationMap, user)
+			s.locationMap[ChatUser{ChatID: to, UserID: user.UserID}] = code
+		}
+	}
+}
+
+// ExpireSessions удаляет сессии, ID последних сообщений и запросы местоположения пользователей,
+// не активных дольше ttl. Выбранный язык сохраняется. Возвращает число удаленных пользователей
+func (s *SurveyService) ExpireSessions(ttl time.Duration) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired, err := s.store.Expire(time.Now().Add(-ttl))
+	if err != nil {
+		log.Println("Error expiring sessions:", err)
+	}
+	for _, user := range expired {
+		delete(s.locationMap, user)
+	}
+	return len(expired)
+}
+
+// SweepSessions раз в interval удаляет сессии, не активные дольше ttl. Работает до закрытия done
+func (s *SurveyService) SweepSessions(ttl, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if expired := s.ExpireSessions(ttl); expired > 0 {
+				log.Printf("Удалено устаревших сессий: %d", expired)
+			}
+		}
+	}
+}
+
+// GetLanguage возвращает язык пользователя или пустую строку, если язык не выбран
+func (s *SurveyService) GetLanguage(userID int64) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.store.Language(userID)
+}
+
+// SetLanguage сохраняет язык пользователя. Язык сохраняется между опросами и чатами
+// и не удаляется вместе с устаревшими сессиями
+func (s *SurveyService) SetLanguage(userID int64, lang string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.SetLanguage(userID, lang); err != nil {
+		log.Println("Error saving language:", err)
+	}
+}
+
+// GetLocationRequest возвращает код исследования, для которого ожидается местоположение участника чата
+func (s *SurveyService) GetLocationRequest(user ChatUser) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.locationMap[user]
+}
+
+// SetLocationRequest запоминает исследование, для которого ожидается местоположение.
+// Пустой код отменяет ожидание
+func (s *SurveyService) SetLocationRequest(user ChatUser, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if code == "" {
+		delete(s.locationMap, user)
+		return
+	}
+	s.locationMap[user] = code
+}
+
+// ToggleSelection отмечает вариант вопроса с множественным выбором или снимает отметку
+func (s *SurveyService) ToggleSelection(key SessionKey, questionID, data string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.Load(key)
+	if !ok {
+		err = errors.New("USER STATE NOT FOUND IN MAP")
+		return
+	}
+
+	if session.Selections == nil {
+		session.Selections = map[string][]string{}
+	}
+
+	selected := session.Selections[questionID]
+	if i := slices.Index(selected, data); i >= 0 {
+		session.Selections[questionID] = slices.Delete(selected, i, i+1)
+	} else {
+		session.Selections[questionID] = append(selected, data)
+	}
+	s.save(key, session)
+	return
+}
+
+// GetSelection возвращает отмеченные варианты вопроса с множественным выбором
+func (s *SurveyService) GetSelection(key SessionKey, questionID string) (selected []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if session, ok := s.store.Load(key); ok {
+		selected = session.Selections[questionID]
+	}
+	return
+}
+
+// SaveAnswer записывает ответ на вопрос для правил перехода
+func (s *SurveyService) SaveAnswer(key SessionKey, questionID string, values ...string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.Load(key)
+	if !ok {
+		err = errors.New("USER STATE NOT FOUND IN MAP")
+		return
+	}
+
+	if session.Answers == nil {
+		session.Answers = Answers{}
+	}
+	session.Answers[questionID] = slices.Clone(values)
+	s.save(key, session)
+	return
+}
+
+// GetAnswers возвращает копию записанных ответов сессии
+func (s *SurveyService) GetAnswers(key SessionKey) (answers Answers) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	answers = Answers{}
+	if session, ok := s.store.Load(key); ok {
+		for questionID, values := range session.Answers {
+			answers[questionID] = values
+		}
+	}
+	return
+}
+
+// StartChecklist начинает проверку критериев исследования
+func (s *SurveyService) StartChecklist(key SessionKey, trial *Trial) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.Load(key)
+	if !ok {
+		session = &Session{survey: CurrentSurvey()}
+	}
+	session.Checklist = &ChecklistSession{Trial: trial.Code, trial: trial}
+	s.save(key, session)
+}
+
+// GetChecklist возвращает проверяемое исследование и ответы на его критерии
+func (s *SurveyService) GetChecklist(key SessionKey) (trial *Trial, answers []CriterionAnswer) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if session, ok := s.store.Load(key); ok && session.Checklist != nil {
+		if trial = session.Checklist.trial; trial == nil {
+			trial = session.Survey().GetTrial(session.Checklist.Trial)
+		}
+		answers = session.Checklist.Answers
 	}
 	return
 }
 
-func (s *SurveyService) SetLastMessageID(userID int64, lastMessageID int) {
+func (s *SurveyService) SaveChecklistAnswer(key SessionKey, answer CriterionAnswer) (err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.lastMessageIDMap[userID] = lastMessageID
+	session, ok := s.store.Load(key)
+	if !ok || session.Checklist == nil {
+		err = errors.New("CHECKLIST NOT FOUND IN MAP")
+		return
+	}
+
+	session.Checklist.Answers = append(session.Checklist.Answers, answer)
+	s.save(key, session)
+	return
+}
+
+func (s *SurveyService) PopChecklistAnswer(key SessionKey) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.store.Load(key)
+	if !ok || session.Checklist == nil {
+		err = errors.New("CHECKLIST NOT FOUND IN MAP")
+		return
+	}
+
+	answersLen := len(session.Checklist.Answers)
+	if answersLen == 0 {
+		err = errors.New("CHECKLIST ANSWERS ARE EMPTY")
+		return
+	}
+
+	session.Checklist.Answers = session.Checklist.Answers[:answersLen-1]
+	s.save(key, session)
 	return
 }
 
@@ -129,10 +426,7 @@ var (
 // GetInstance возвращает единственный экземпляр SurveyManager
 func GetInstance() *SurveyService {
 	once.Do(func() {
-		instance = &SurveyService{
-			userAnswersMap:   make(map[int64]*userAnswers),
-			lastMessageIDMap: make(map[int64]int),
-		}
+		instance = newSurveyService(NewMemorySessionStore())
 	})
 	return instance
 }
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Problem Структурная ошибка в дереве опросника
+type Problem struct {
+	Location string // ID вопроса и/или data варианта ответа, код исследования
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("[%s] %s", p.Location, p.Message)
+}
+
+// Validate проверяет дерево вопросов и реестр исследований на структурные ошибки
+func (s *Survey) Validate() (problems []Problem) {
+	var (
+		seenData        = map[string]string{}
+		usedTrial       = map[string]bool{}
+		visited         = map[*Question]bool{}
+		seenID          = map[string]*Question{}
+		byID, askedFrom = s.questionPaths()
+		walk            func(question *Question)
+	)
+
+	report := func(location, format string, args ...any) {
+		problems = append(problems, Problem{Location: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	walk = func(question *Question) {
+		// Вопрос, на который ведет несколько переходов, проверяется один раз
+		if visited[question] {
+			return
+		}
+		visited[question] = true
+
+		if prev, ok := seenID[question.ID]; ok && prev != question {
+			report(question.ID, "ID вопроса повторяется, ссылки next и условия правил неоднозначны")
+		}
+		seenID[question.ID] = question
+
+		if strings.TrimSpace(question.Text) == "" {
+			report(question.ID, "пустой текст вопроса")
+		}
+
+		switch question.Type {
+		case "", QuestionChoice:
+			if len(question.Options) == 0 {
+				report(question.ID, "у вопроса нет вариантов ответа")
+			}
+			if len(question.Routes) > 0 {
+				report(question.ID, "правила перехода (routes) не поддерживаются вопросом с выбором одного варианта")
+			}
+		case QuestionNumber:
+			if len(question.Routes) == 0 {
+				report(question.ID, "у вопроса с вводом числа нет правил перехода (routes)")
+			}
+			if len(question.Options) > 0 {
+				report(question.ID, "у вопроса с вводом числа не должно быть вариантов ответа (options)")
+			}
+			if in := question.Input; in != nil && in.Min != nil && in.Max != nil && *in.Min > *in.Max {
+				report(question.ID, "минимальное значение ввода больше максимального")
+			}
+		case QuestionMulti:
+			if len(question.Options) == 0 {
+				report(question.ID, "у вопроса нет вариантов ответа")
+			}
+			if len(question.Routes) == 0 {
+				report(question.ID, "у вопроса с множественным выбором нет правил перехода (routes)")
+			}
+		default:
+			report(question.ID, "неизвестный тип вопроса %q", question.Type)
+		}
+
+		// Кнопки вариантов ответа
+		for i := range question.Options {
+			option := &question.Options[i]
+			location := question.ID + " / " + option.Data
+
+			if strings.TrimSpace(option.Text) == "" {
+				report(location, "пустой текст варианта ответа")
+			}
+			if len(option.Data) > MaxCallbackDataLen {
+				report(location, "data длиннее %d байт (%d)", MaxCallbackDataLen, len(option.Data))
+			}
+			if IsReservedCallbackData(option.Data) {
+				report(location, "data %q зарезервировано ботом", option.Data)
+			}
+			if question.IsMulti() && (option.IsTerminal() || option.NextQuestion != nil) {
+				report(location, "вариант множественного выбора не должен содержать переходов, используйте routes")
+			}
+		}
+
+		// Правила перехода
+		for i := range question.Routes {
+			route := &question.Routes[i]
+			location := question.ID + " / " + route.Data
+
+			if route.Min != nil && route.Max != nil && *route.Min > *route.Max {
+				report(location, "нижняя граница правила больше верхней")
+			}
+			if !question.IsNumber() && (route.Min != nil || route.Max != nil) {
+				report(location, "границы min/max поддерживаются только вопросами с вводом числа")
+			}
+			if !question.IsMulti() && (len(route.All) > 0 || len(route.Any) > 0 || len(route.None) > 0) {
+				report(location, "условия all/any/none поддерживаются только вопросами с множественным выбором")
+			}
+			for _, data := range slices.Concat(route.All, route.Any, route.None) {
+				if !slices.ContainsFunc(question.Options, func(option Option) bool { return option.Matches(data) }) {
+					report(location, "условие ссылается на неизвестный вариант %q", data)
+				}
+			}
+		}
+
+		// Правила по ответам сессии
+		unconditional := ""
+		for i := range question.Rules {
+			rule := &question.Rules[i]
+			location := question.ID + " / " + rule.Data
+
+			conditions, err := rule.ParseConditions()
+			if err != nil {
+				report(location, "некорректное условие правила: %v", err)
+				continue
+			}
+
+			switch {
+			case unconditional != "":
+				report(location, "правило недостижимо: правило %s без условий срабатывает раньше", unconditional)
+			case len(conditions) == 0:
+				unconditional = rule.Data
+			}
+			for _, prev := range question.Rules[:i] {
+				if len(conditions) > 0 && prev.When == rule.When {
+					report(location, "правило недостижимо: условие повторяет правило %s", prev.Data)
+				}
+			}
+
+			for _, condition := range conditions {
+				ref, ok := byID[condition.QuestionID]
+				switch {
+				case !ok:
+					report(location, "условие ссылается на неизвестный вопрос %q", condition.QuestionID)
+				case condition.QuestionID != question.ID && !askedFrom[question][condition.QuestionID]:
+					report(location, "правило недостижимо: вопрос %s не задается раньше вопроса %s", condition.QuestionID, question.ID)
+				case condition.IsNumeric() && !ref.IsNumber():
+					report(location, "сравнение чисел с ответом на вопрос %s без ввода числа", condition.QuestionID)
+				case !condition.IsNumeric() && ref.IsNumber():
+					if _, err = strconv.ParseFloat(condition.Value, 64); err != nil {
+						report(location, "ответ на вопрос %s — число, а не %q", condition.QuestionID, condition.Value)
+					}
+				case !condition.IsNumeric() && !slices.ContainsFunc(ref.Options, func(option Option) bool { return option.Matches(condition.Value) }):
+					report(location, "правило недостижимо: у вопроса %s нет варианта %q", condition.QuestionID, condition.Value)
+				}
+			}
+
+			if conditionsContradict(conditions, byID) {
+				report(location, "правило недостижимо: условия противоречат друг другу")
+			}
+		}
+
+		for _, option := range slices.Concat(question.Options, routeOptions(question.Routes), ruleOptions(question.Rules)) {
+			if option.Data == "" {
+				report(question.ID+" / "+option.Text, "пустое значение data")
+			} else if prev, ok := seenData[option.Data]; ok {
+				report(question.ID+" / "+option.Data, "data %q уже используется в вопросе %s", option.Data, prev)
+			} else {
+				seenData[option.Data] = question.ID
+			}
+		}
+
+		// Переходы к следующим вопросам и исследованиям
+		for _, option := range question.Targets() {
+			location := question.ID + " / " + option.Data
+
+			if len(option.Trials) > 1 {
+				for _, code := range option.Trials {
+					if len(PageCallbackData(ResultsCallbackData(option.Data, code), 99)) > MaxCallbackDataLen {
+						report(location, "data и код исследования %q слишком длинные для кнопки исследования в списке", code)
+					}
+				}
+			}
+
+			switch {
+			case option.IsTerminal() && option.NextQuestion != nil:
+				report(location, "вариант одновременно конечный (trials) и ведет к следующему вопросу")
+			case !option.IsTerminal() && option.NextQuestion == nil:
+				report(location, "вариант не конечный и не ведет к следующему вопросу")
+			}
+
+			for j, code := range option.Trials {
+				usedTrial[code] = true
+				if s.GetTrial(code) == nil {
+					report(location, "исследование %q отсутствует в реестре", code)
+				}
+				if slices.Contains(option.Trials[:j], code) {
+					report(location, "исследование %q указано повторно", code)
+				}
+			}
+
+			if option.NextQuestion != nil {
+				walk(option.NextQuestion)
+			}
+		}
+	}
+
+	for i := range s.Questions {
+		if i > 0 && !visited[&s.Questions[i]] {
+			report(s.Questions[i].ID, "общий вопрос не используется ни одним вариантом ответа")
+		}
+		walk(&s.Questions[i])
+	}
+
+	seenCode := map[string]bool{}
+	nosologies := s.Nosologies()
+	for _, trial := range s.Trials {
+		switch {
+		case trial.Code == "":
+			report(trial.Title, "у исследования не указан код")
+			continue
+		case seenCode[trial.Code]:
+			report(trial.Code, "код исследования повторяется в реестре")
+		}
+		seenCode[trial.Code] = true
+
+		if strings.TrimSpace(trial.Title) == "" {
+			report(trial.Code, "у исследования нет названия")
+		}
+		switch trial.Status {
+		case "", StatusRecruiting, StatusPaused, StatusClosed:
+		default:
+			report(trial.Code, "неизвестный статус набора %q", trial.Status)
+		}
+		if trial.Nosology != "" && !slices.Contains(nosologies, trial.Nosology) {
+			report(trial.Code, "нозология %q не совпадает ни с одним ответом на первый вопрос", trial.Nosology)
+		}
+		if trial.EnrollmentStart != nil && trial.EnrollmentEnd != nil && trial.EnrollmentEnd.Before(*trial.EnrollmentStart) {
+			report(trial.Code, "дата окончания набора раньше даты начала")
+		}
+		if len(trial.Inclusion) == 0 {
+			report(trial.Code, "у исследования нет критериев включения")
+		}
+		for _, document := range trial.Documents {
+			if info, err := os.Stat(document.Path()); err != nil || info.IsDir() {
+				report(trial.Code, "файл документа %q не найден", document.File)
+			}
+		}
+		for _, site := range trial.Sites {
+			if site.Telegram != "" && site.ChatURL() == "" {
+				report(trial.Code, "центр %s: некорректное имя координатора в Telegram %q", site.Name, site.Telegram)
+			}
+			if math.Abs(site.Lat) > 90 || math.Abs(site.Lon) > 180 {
+				report(trial.Code, "центр %s: некорректные координаты %v, %v", site.Name, site.Lat, site.Lon)
+			}
+		}
+		if len(CallbackCheckPrefix+trial.Code) > MaxCallbackDataLen || len(PageCallbackData(CallbackTrialPrefix+trial.Code, 99)) > MaxCallbackDataLen {
+			report(trial.Code, "код исследования слишком длинный для callback data")
+		}
+		if !usedTrial[trial.Code] {
+			report(trial.Code, "исследование не используется ни одним конечным вариантом ответа")
+		}
+	}
+
+	return
+}
+
+// questionPaths собирает вопросы дерева по ID и для каждого вопроса ID вопросов,
+// на которые пользователь мог ответить раньше него хотя бы на одном пути
+func (s *Survey) questionPaths() (byID map[string]*Question, askedFrom map[*Question]map[string]bool) {
+	byID = map[string]*Question{}
+	askedFrom = map[*Question]map[string]bool{}
+
+	var visit func(question *Question, path []string)
+	visit = func(question *Question, path []string) {
+		asked, ok := askedFrom[question]
+		grown := !ok
+		if !ok {
+			asked = map[string]bool{}
+			askedFrom[question] = asked
+		}
+		for _, id := range path {
+			if !asked[id] {
+				asked[id] = true
+				grown = true
+			}
+		}
+		// Путь не добавил новых предшествующих вопросов, потомков обходить повторно не нужно
+		if !grown {
+			return
+		}
+
+		if _, ok = byID[question.ID]; !ok {
+			byID[question.ID] = question
+		}
+
+		next := append(slices.Clone(path), question.ID)
+		for _, option := range question.Targets() {
+			if option.NextQuestion != nil {
+				visit(option.NextQuestion, next)
+			}
+		}
+	}
+
+	for i := range s.Questions {
+		visit(&s.Questions[i], nil)
+	}
+	return
+}
+
+// conditionsContradict проверяет, что условия правила не могут выполниться одновременно:
+// разные варианты одного вопроса с выбором одного ответа или пустой диапазон числа
+func conditionsContradict(conditions []Condition, byID map[string]*Question) bool {
+	type bounds struct {
+		low, high             float64
+		lowStrict, highStrict bool
+	}
+	var (
+		equal   = map[string]string{}
+		numbers = map[string]*bounds{}
+	)
+
+	for _, condition := range conditions {
+		question := byID[condition.QuestionID]
+
+		switch condition.Operator {
+		case "=":
+			if prev, ok := equal[condition.QuestionID]; ok && prev != condition.Value && question != nil && !question.IsMulti() {
+				return true
+			}
+			equal[condition.QuestionID] = condition.Value
+			continue
+		case "!=":
+			continue
+		}
+
+		value, err := strconv.ParseFloat(condition.Value, 64)
+		if err != nil {
+			continue
+		}
+		b, ok := numbers[condition.QuestionID]
+		if !ok {
+			b = &bounds{low: math.Inf(-1), high: math.Inf(1)}
+			numbers[condition.QuestionID] = b
+		}
+		switch condition.Operator {
+		case ">", ">=":
+			if value > b.low || (value == b.low && condition.Operator == ">") {
+				b.low, b.lowStrict = value, condition.Operator == ">"
+			}
+		case "<", "<=":
+			if value < b.high || (value == b.high && condition.Operator == "<") {
+				b.high, b.highStrict = value, condition.Operator == "<"
+			}
+		}
+		if b.low > b.high || (b.low == b.high && (b.lowStrict || b.highStrict)) {
+			return true
+		}
+	}
+
+	// Равенство и неравенство одному и тому же варианту
+	for _, condition := range conditions {
+		if condition.Operator == "!=" && equal[condition.QuestionID] == condition.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleOptions(rules []Rule) (options []Option) {
+	for _, rule := range rules {
+		options = append(options, rule.Option)
+	}
+	return
+}
+
+func routeOptions(routes []Route) (options []Option) {
+	for _, route := range routes {
+		options = append(options, route.Option)
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCleanSurvey(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", surveyYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, survey.Validate())
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	survey := &Survey{
+		Questions: []Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []Option{
+					{Text: "Вариант 1", Data: "dup", Trials: []string{"A"}},
+					{Text: "Вариант 2", Data: "dup", Trials: []string{"A"}},
+					{
+						Text:         "Вариант 3",
+						Data:         "both",
+						Trials:       []string{"A"},
+						NextQuestion: &Question{ID: "q1_1", Options: []Option{{Text: "Да", Data: "q1_1_option1", Trials: []string{"A"}}}},
+					},
+					{Text: "Вариант 4", Data: CallbackBack, Trials: []string{"A"}},
+					{Text: "Вариант 6", Data: CallbackResultsPrefix + "A", Trials: []string{"A", "A"}},
+					{Text: "Вариант 5", Data: strings.Repeat("x", MaxCallbackDataLen+1), Trials: []string{"F"}},
+					{Text: "Вариант 7", Data: "multi", NextQuestion: &Question{
+						ID:      "q1_2",
+						Text:    "Отметьте мутации",
+						Type:    QuestionMulti,
+						Options: []Option{{Text: "EGFR", Data: "q1_2_egfr", Trials: []string{"A"}}},
+						Routes:  []Route{{All: []string{"q1_2_kras"}, Option: Option{Data: "q1_2_route", Trials: []string{"A"}}}},
+					}},
+				},
+			},
+		},
+		Trials: []Trial{
+			{Code: "A", Title: "Исследование A", Inclusion: []string{"Критерий"}},
+			{Code: "A", Title: "Дубликат A", Inclusion: []string{"Критерий"}},
+			{Code: "orphan", Title: "Исследование без ссылок", Status: "open"},
+		},
+	}
+
+	var messages []string
+	for _, problem := range survey.Validate() {
+		messages = append(messages, problem.String())
+	}
+	report := strings.Join(messages, "\n")
+
+	assert.Contains(t, report, `data "dup" уже используется`)
+	assert.Contains(t, report, "одновременно конечный")
+	assert.Contains(t, report, "[q1_1] пустой текст вопроса")
+	assert.Contains(t, report, `data "back" зарезервировано`)
+	assert.Contains(t, report, `data "results:A" зарезервировано`)
+	assert.Contains(t, report, `исследование "A" указано повторно`)
+	assert.Contains(t, report, "data длиннее 64 байт")
+	assert.Contains(t, report, `исследование "F" отсутствует в реестре`)
+	assert.Contains(t, report, "[q1_2 / q1_2_egfr] вариант множественного выбора не должен содержать переходов")
+	assert.Contains(t, report, `[q1_2 / q1_2_route] условие ссылается на неизвестный вариант "q1_2_kras"`)
+	assert.Contains(t, report, "[A] код исследования повторяется")
+	assert.Contains(t, report, "[orphan] у исследования нет критериев включения")
+	assert.Contains(t, report, "[orphan] исследование не используется")
+	assert.Contains(t, report, `[orphan] неизвестный статус набора "open"`)
+}
+
+func TestValidateDefaultSurvey(t *testing.T) {
+	assert.Empty(t, DefaultSurvey().Validate(), "Встроенный опросник должен проходить проверку")
+}
+
+func TestValidateRules(t *testing.T) {
+	line := &Question{
+		ID:     "line",
+		Text:   "Укажите линию терапии",
+		Type:   QuestionNumber,
+		Routes: []Route{{Option: Option{Data: "line_any", Trials: []string{"A"}}}},
+		Rules: []Rule{
+			{When: "nosology = lung and line >= 2", Option: Option{Data: "lung_second", Trials: []string{"B"}}},
+			{When: "nosology = lung and line >= 2", Option: Option{Data: "duplicate", Trials: []string{"B"}}},
+			{When: "nosology = lung and nosology = melanoma", Option: Option{Data: "both", Trials: []string{"B"}}},
+			{When: "line > 3 and line < 2", Option: Option{Data: "empty_range", Trials: []string{"B"}}},
+			{When: "ecog <= 1", Option: Option{Data: "later", Trials: []string{"B"}}},
+			{When: "nosology = breast", Option: Option{Data: "no_option", Trials: []string{"B"}}},
+			{When: "nosology > 1", Option: Option{Data: "not_number", Trials: []string{"B"}}},
+			{When: "stage = III", Option: Option{Data: "unknown", Trials: []string{"B"}}},
+			{When: "line >", Option: Option{Data: "broken", Trials: []string{"B"}}},
+			{Option: Option{Data: "fallback", Trials: []string{"A"}}},
+			{When: "line = 1", Option: Option{Data: "after_fallback", Trials: []string{"A"}}},
+		},
+	}
+	ecog := &Question{
+		ID:     "ecog",
+		Text:   "Укажите балл ECOG",
+		Type:   QuestionNumber,
+		Routes: []Route{{Option: Option{Data: "ecog_any", NextQuestion: line}}},
+	}
+	survey := &Survey{
+		Questions: []Question{
+			{
+				ID:   "nosology",
+				Text: "Выберите нозологию",
+				Options: []Option{
+					// Вопрос о линии терапии общий для двух веток
+					{Text: "Рак легкого", Data: "lung", NextQuestion: line},
+					{Text: "Меланома", Data: "melanoma", NextQuestion: ecog},
+				},
+			},
+		},
+		Trials: []Trial{
+			{Code: "A", Title: "Исследование A", Inclusion: []string{"Критерий"}},
+			{Code: "B", Title: "Исследование B", Inclusion: []string{"Критерий"}},
+		},
+	}
+
+	var messages []string
+	for _, problem := range survey.Validate() {
+		messages = append(messages, problem.String())
+	}
+	report := strings.Join(messages, "\n")
+
+	assert.NotContains(t, report, "[line / lung_second]", "Достижимое правило")
+	assert.NotContains(t, report, "уже используется", "Общий вопрос проверяется один раз")
+	assert.Contains(t, report, "[line / duplicate] правило недостижимо: условие повторяет правило lung_second")
+	assert.Contains(t, report, "[line / both] правило недостижимо: условия противоречат друг другу")
+	assert.Contains(t, report, "[line / empty_range] правило недостижимо: условия противоречат друг другу")
+	assert.Contains(t, report, "[line / no_option] правило недостижимо: у вопроса nosology нет варианта \"breast\"")
+	assert.Contains(t, report, "[line / not_number] сравнение чисел с ответом на вопрос nosology")
+	assert.Contains(t, report, "[line / unknown] условие ссылается на неизвестный вопрос \"stage\"")
+	assert.Contains(t, report, "[line / broken] некорректное условие правила")
+	assert.Contains(t, report, "[line / after_fallback] правило недостижимо: правило fallback без условий срабатывает раньше")
+	// ECOG задается только в ветке меланомы до вопроса о линии, поэтому правило допустимо
+	assert.NotContains(t, report, "[line / later]")
+
+	// Вопрос, который задается только после, делает правило недостижимым
+	ecog.Rules = []Rule{{When: "line >= 2", Option: Option{Data: "ecog_rule", Trials: []string{"B"}}}}
+	assert.Contains(t, fmt.Sprint(survey.Validate()), "[ecog / ecog_rule] правило недостижимо: вопрос line не задается раньше вопроса ecog")
+}
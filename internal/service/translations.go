@@ -0,0 +1,22 @@
+package service
+
+// Translations Переводы текста по коду языка. Русский текст хранится в основном поле
+type Translations map[string]string
+
+// In возвращает перевод на язык lang или исходный текст, если перевода нет
+func (t Translations) In(lang, text string) string {
+	if translated, ok := t[lang]; ok && translated != "" {
+		return translated
+	}
+	return text
+}
+
+// TextIn возвращает текст вопроса на языке lang
+func (q *Question) TextIn(lang string) string {
+	return q.I18n.In(lang, q.Text)
+}
+
+// TextIn возвращает текст варианта ответа на языке lang
+func (o *Option) TextIn(lang string) string {
+	return o.I18n.In(lang, o.Text)
+}
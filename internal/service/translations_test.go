@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", `
+questions:
+  - id: q1
+    text: Выберите нозологию
+    i18n: {en: Choose the cancer type}
+    options:
+      - text: Меланома
+        data: q1_option1
+        i18n: {en: Melanoma}
+        trials: [MIT-002]
+trials:
+  - code: MIT-002
+    title: Исследование MIT-002
+    inclusion: [Меланома, Возраст от 18 лет]
+    exclusion: [Метастазы в ЦНС]
+    i18n:
+      en:
+        title: MIT-002 study
+        inclusion: [Melanoma, Age 18 or older]
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	question := &survey.Questions[0]
+	assert.Equal(t, "Choose the cancer type", question.TextIn("en"))
+	assert.Equal(t, "Выберите нозологию", question.TextIn("ru"))
+	assert.Equal(t, "Выберите нозологию", question.TextIn("de"), "Нет перевода - русский текст")
+	assert.Equal(t, "Melanoma", question.Options[0].TextIn("en"))
+
+	trial := survey.GetTrial("MIT-002")
+	english := trial.In("en")
+	assert.Equal(t, "MIT-002 study", english.Title)
+	assert.Equal(t, []string{"Melanoma", "Age 18 or older"}, english.Inclusion)
+	assert.Equal(t, []string{"Метастазы в ЦНС"}, english.Exclusion, "Непереведенные критерии остаются на русском")
+	assert.Equal(t, "Исследование MIT-002", trial.Title, "Исходное описание не меняется")
+	assert.Same(t, trial, trial.In("ru"))
+
+	assert.Equal(t, "enrollment open", trial.LocalizedStatusText("en", *date("2025-01-01")))
+	assert.Equal(t, "набор открыт", trial.StatusText(*date("2025-01-01")))
+}
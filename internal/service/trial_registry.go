@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// registryFile Файл реестра исследований, отделенный от дерева вопросов
+type registryFile struct {
+	Trials []Trial `yaml:"trials"`
+}
+
+// RegistryPath возвращает путь к файлу реестра с учетом расположения файла опросника surveyPath
+func (s *Survey) RegistryPath(surveyPath string) string {
+	if s.TrialsFile == "" || filepath.IsAbs(s.TrialsFile) {
+		return s.TrialsFile
+	}
+	return filepath.Join(filepath.Dir(surveyPath), s.TrialsFile)
+}
+
+// LoadTrials читает реестр исследований из YAML или JSON файла
+func LoadTrials(path string) ([]Trial, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("READ TRIALS FILE: %w", err)
+	}
+
+	var registry registryFile
+	if err = yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("PARSE TRIALS FILE %s: %w", path, err)
+	}
+	return registry.Trials, nil
+}
+
+// SaveTrials сохраняет реестр исследований в YAML файл
+func SaveTrials(path string, trials []Trial) error {
+	data, err := yaml.Marshal(registryFile{Trials: trials})
+	if err != nil {
+		return fmt.Errorf("MARSHAL TRIALS: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("WRITE TRIALS FILE: %w", err)
+	}
+	return nil
+}
+
+// MergeRegistry дополняет исследования из файла опросника реестром.
+// Исследование из реестра заменяет исследование с тем же кодом, новые добавляются в конец
+func MergeRegistry(trials, registry []Trial) []Trial {
+	merged := append([]Trial{}, trials...)
+
+	for _, trial := range registry {
+		replaced := false
+		for i := range merged {
+			if merged[i].Code == trial.Code {
+				merged[i] = trial
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, trial)
+		}
+	}
+	return merged
+}
+
+// resolveDocuments Запоминает пути документов исследований, заданные относительно каталога dir
+func resolveDocuments(trials []Trial, dir string) {
+	for i := range trials {
+		for j := range trials[i].Documents {
+			document := &trials[i].Documents[j]
+			if document.File != "" && !filepath.IsAbs(document.File) {
+				document.path = filepath.Join(dir, document.File)
+			}
+		}
+	}
+}
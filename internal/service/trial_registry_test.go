@@ -0,0 +1,73 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTrialsFile(t *testing.T) {
+	dir := t.TempDir()
+	surveyPath := filepath.Join(dir, "survey.yaml")
+	if err := os.WriteFile(surveyPath, []byte(surveyYAML+"trials_file: trials.yaml\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := []Trial{
+		{Code: "MIT-002", Title: "Обновленное название", Nosology: "Рак легкого", Inclusion: []string{"Критерий"}, Contacts: "+7 900 000-00-00"},
+		{Code: "NEW-001", Title: "Новое исследование", Inclusion: []string{"Критерий"}},
+	}
+	if !assert.NoError(t, SaveTrials(filepath.Join(dir, "trials.yaml"), registry)) {
+		return
+	}
+
+	survey, err := LoadSurvey(surveyPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, survey.Trials, 2)
+	assert.Equal(t, "Обновленное название", survey.GetTrial("MIT-002").Title, "Реестр заменяет исследование из опросника")
+	assert.Equal(t, "+7 900 000-00-00", survey.GetTrial("MIT-002").Contacts)
+	assert.NotNil(t, survey.GetTrial("NEW-001"))
+
+	// До первого импорта реестра нет: опросник загружается с исследованиями из своего файла
+	survey, err = LoadSurvey(writeSurveyFile(t, "survey.yaml", surveyYAML+"trials_file: missing.yaml\n"))
+	if assert.NoError(t, err, "Отсутствующий реестр") {
+		assert.NotNil(t, survey.GetTrial("MIT-002"))
+	}
+
+	brokenPath := writeSurveyFile(t, "survey.yaml", surveyYAML+"trials_file: trials.yaml\n")
+	if err = os.WriteFile(filepath.Join(filepath.Dir(brokenPath), "trials.yaml"), []byte("trials: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadSurvey(brokenPath)
+	assert.Error(t, err, "Поврежденный реестр")
+}
+
+func TestRegistryPath(t *testing.T) {
+	survey := &Survey{TrialsFile: "trials.yaml"}
+	assert.Equal(t, filepath.Join("content", "trials.yaml"), survey.RegistryPath(filepath.Join("content", "survey.yaml")))
+
+	survey.TrialsFile = "/srv/trials.yaml"
+	assert.Equal(t, "/srv/trials.yaml", survey.RegistryPath("survey.yaml"))
+
+	assert.Empty(t, (&Survey{}).RegistryPath("survey.yaml"))
+}
+
+func TestValidateNosology(t *testing.T) {
+	survey, err := LoadSurvey(writeSurveyFile(t, "survey.yaml", surveyYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Рак легкого", "Меланома"}, survey.Nosologies())
+
+	survey.Trials[0].Nosology = "Рак желудка"
+	var messages []string
+	for _, problem := range survey.Validate() {
+		messages = append(messages, problem.String())
+	}
+	assert.Contains(t, strings.Join(messages, "\n"), `[MIT-002] нозология "Рак желудка" не совпадает ни с одним ответом на первый вопрос`)
+}
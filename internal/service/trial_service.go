@@ -0,0 +1,168 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/i18n"
+)
+
+// TrialStatus Статус набора пациентов в исследование
+type TrialStatus string
+
+const (
+	StatusRecruiting TrialStatus = "recruiting" // набор открыт
+	StatusPaused     TrialStatus = "paused"     // набор приостановлен
+	StatusClosed     TrialStatus = "closed"     // набор завершен
+)
+
+// Trial Структура клинического исследования
+type Trial struct {
+	Code            string               `yaml:"code"`
+	NCT             string               `yaml:"nct,omitempty"` // Номер в ClinicalTrials.gov для международных исследований
+	Title           string               `yaml:"title"`
+	Nosology        string               `yaml:"nosology,omitempty"` // Нозология из таблицы координаторов
+	Sponsor         string               `yaml:"sponsor,omitempty"`
+	Phase           string               `yaml:"phase,omitempty"`
+	Conditions      []string             `yaml:"conditions,omitempty"` // Заболевания по данным ClinicalTrials.gov
+	Inclusion       []string             `yaml:"inclusion"`            // Критерии включения
+	Exclusion       []string             `yaml:"exclusion"`            // Критерии невключения
+	Sites           []Site               `yaml:"sites,omitempty"`
+	Documents       []Document           `yaml:"documents,omitempty"`        // Синопсис протокола, информация для пациента
+	Contacts        string               `yaml:"contacts,omitempty"`         // Контакты для направления пациента
+	Status          TrialStatus          `yaml:"status,omitempty"`           // Пустой статус равнозначен recruiting
+	EnrollmentStart *time.Time           `yaml:"enrollment_start,omitempty"` // Дата начала набора (если известна)
+	EnrollmentEnd   *time.Time           `yaml:"enrollment_end,omitempty"`   // Дата окончания набора (если известна)
+	I18n            map[string]TrialText `yaml:"i18n,omitempty"`             // Описание на других языках
+}
+
+// TrialText Переводимые поля описания исследования
+type TrialText struct {
+	Title     string   `yaml:"title,omitempty"`
+	Inclusion []string `yaml:"inclusion,omitempty"`
+	Exclusion []string `yaml:"exclusion,omitempty"`
+}
+
+// Document Документ исследования (PDF), который бот отправляет по кнопке "📄 Синопсис"
+type Document struct {
+	Title string `yaml:"title,omitempty"`
+	File  string `yaml:"file"` // Путь к файлу относительно файла опросника или реестра
+
+	// path Путь к файлу относительно рабочего каталога бота. Заполняется при загрузке,
+	// File остается как в файле, чтобы импорт сохранял реестр без изменения путей
+	path string
+}
+
+// Path возвращает путь, по которому бот читает файл документа
+func (d Document) Path() string {
+	if d.path != "" {
+		return d.path
+	}
+	return d.File
+}
+
+// Site Структура исследовательского центра
+type Site struct {
+	Name         string  `yaml:"name"`
+	City         string  `yaml:"city,omitempty"`
+	Address      string  `yaml:"address,omitempty"`
+	Investigator string  `yaml:"investigator,omitempty"` // Главный исследователь центра
+	Phone        string  `yaml:"phone,omitempty"`        // Телефон координатора
+	Telegram     string  `yaml:"telegram,omitempty"`     // Имя пользователя координатора в Telegram
+	Lat          float64 `yaml:"lat,omitempty"`          // Широта для поиска ближайшего центра
+	Lon          float64 `yaml:"lon,omitempty"`          // Долгота для поиска ближайшего центра
+}
+
+// telegramUsername Допустимое имя пользователя Telegram
+var telegramUsername = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+
+// TelegramUsername возвращает имя пользователя координатора без "@" и ссылки t.me
+func (s *Site) TelegramUsername() string {
+	username := strings.TrimSpace(s.Telegram)
+	for _, prefix := range []string{"https://", "http://", "t.me/", "@"} {
+		username = strings.TrimPrefix(username, prefix)
+	}
+	return username
+}
+
+// ChatURL возвращает ссылку на личный чат с координатором или пустую строку, если имя не указано или некорректно
+func (s *Site) ChatURL() string {
+	username := s.TelegramUsername()
+	if !telegramUsername.MatchString(username) {
+		return ""
+	}
+	return "https://t.me/" + username
+}
+
+// IsRecruiting проверяет, идет ли набор пациентов на момент now
+func (t *Trial) IsRecruiting(now time.Time) bool {
+	if t.Status != "" && t.Status != StatusRecruiting {
+		return false
+	}
+	if t.EnrollmentStart != nil && now.Before(*t.EnrollmentStart) {
+		return false
+	}
+	return !t.enrollmentEnded(now)
+}
+
+// IsClosed проверяет, завершен ли набор окончательно (в отличие от приостановки)
+func (t *Trial) IsClosed(now time.Time) bool {
+	return t.Status == StatusClosed || t.enrollmentEnded(now)
+}
+
+// StatusText Описание статуса набора на момент now
+func (t *Trial) StatusText(now time.Time) string {
+	return t.LocalizedStatusText(i18n.DefaultLanguage, now)
+}
+
+// LocalizedStatusText Описание статуса набора на момент now на языке lang
+func (t *Trial) LocalizedStatusText(lang string, now time.Time) string {
+	switch {
+	case t.IsClosed(now):
+		return i18n.T(lang, "набор завершен")
+	case t.Status == StatusPaused:
+		return i18n.T(lang, "набор приостановлен")
+	case t.EnrollmentStart != nil && now.Before(*t.EnrollmentStart):
+		return i18n.T(lang, "набор начнется %s", t.EnrollmentStart.Format("02.01.2006"))
+	default:
+		return i18n.T(lang, "набор открыт")
+	}
+}
+
+// EnrollmentPeriod Сроки набора пациентов на языке lang или пустая строка, если они не указаны
+func (t *Trial) EnrollmentPeriod(lang string) (period string) {
+	if t.EnrollmentStart != nil {
+		period = i18n.T(lang, "с %s", t.EnrollmentStart.Format("02.01.2006"))
+	}
+	if t.EnrollmentEnd != nil {
+		period = strings.TrimSpace(period + " " + i18n.T(lang, "по %s", t.EnrollmentEnd.Format("02.01.2006")))
+	}
+	return
+}
+
+// In возвращает копию исследования с описанием на языке lang.
+// Непереведенные поля остаются на русском
+func (t *Trial) In(lang string) *Trial {
+	text, ok := t.I18n[lang]
+	if !ok {
+		return t
+	}
+
+	localized := *t
+	if text.Title != "" {
+		localized.Title = text.Title
+	}
+	if len(text.Inclusion) > 0 {
+		localized.Inclusion = text.Inclusion
+	}
+	if len(text.Exclusion) > 0 {
+		localized.Exclusion = text.Exclusion
+	}
+	return &localized
+}
+
+func (t *Trial) enrollmentEnded(now time.Time) bool {
+	// Дата окончания включительно: набор идет до конца указанного дня
+	return t.EnrollmentEnd != nil && !now.Before(t.EnrollmentEnd.AddDate(0, 0, 1))
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) *time.Time {
+	parsed, _ := time.Parse("2006-01-02", value)
+	return &parsed
+}
+
+func TestTrialRecruitmentStatus(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		trial      Trial
+		recruiting bool
+		closed     bool
+	}{
+		{"статус не указан", Trial{}, true, false},
+		{"набор открыт", Trial{Status: StatusRecruiting}, true, false},
+		{"набор приостановлен", Trial{Status: StatusPaused}, false, false},
+		{"набор закрыт", Trial{Status: StatusClosed}, false, true},
+		{"набор еще не начался", Trial{EnrollmentStart: date("2025-07-01")}, false, false},
+		{"последний день набора", Trial{EnrollmentEnd: date("2025-06-15")}, true, false},
+		{"срок набора истек", Trial{Status: StatusRecruiting, EnrollmentEnd: date("2025-06-14")}, false, true},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.recruiting, c.trial.IsRecruiting(now), c.name)
+		assert.Equal(t, c.closed, c.trial.IsClosed(now), c.name)
+	}
+}
+
+func TestIsOptionAvailable(t *testing.T) {
+	now := time.Now()
+	survey := &Survey{
+		Questions: []Question{
+			{
+				ID:   "q1",
+				Text: "Выберите нозологию",
+				Options: []Option{
+					{Text: "Только закрытые", Data: "q1_option1", Trials: []string{"CLOSED"}},
+					{Text: "Приостановлено", Data: "q1_option2", Trials: []string{"PAUSED"}},
+					{
+						Text: "Ветка с закрытыми",
+						Data: "q1_option3",
+						NextQuestion: &Question{ID: "q1_3", Text: "Линия", Options: []Option{
+							{Text: "1 линия", Data: "q1_3_option1", Trials: []string{"CLOSED"}},
+						}},
+					},
+					{Text: "Открытое и закрытое", Data: "q1_option4", Trials: []string{"CLOSED", "OPEN"}},
+				},
+			},
+		},
+		Trials: []Trial{
+			{Code: "OPEN", Status: StatusRecruiting},
+			{Code: "PAUSED", Status: StatusPaused},
+			{Code: "CLOSED", Status: StatusClosed},
+		},
+	}
+
+	options := survey.Questions[0].Options
+	assert.False(t, survey.IsOptionAvailable(&options[0], now), "Только закрытые исследования скрываются")
+	assert.True(t, survey.IsOptionAvailable(&options[1], now), "Приостановленные исследования показываются")
+	assert.False(t, survey.IsOptionAvailable(&options[2], now), "Ветка без открытых исследований скрывается")
+	assert.True(t, survey.IsOptionAvailable(&options[3], now))
+}
+
+func TestSiteChatURL(t *testing.T) {
+	cases := map[string]string{
+		"coord_lung":               "https://t.me/coord_lung",
+		"@coord_lung":              "https://t.me/coord_lung",
+		" https://t.me/coord_lung": "https://t.me/coord_lung",
+		"t.me/coord_lung":          "https://t.me/coord_lung",
+		"bad name":                 "",
+		"abc":                      "",
+		"":                         "",
+	}
+	for telegram, url := range cases {
+		site := Site{Telegram: telegram}
+		assert.Equal(t, url, site.ChatURL(), telegram)
+	}
+}
+
+func TestNearestSites(t *testing.T) {
+	assert.InDelta(t, 634, Distance(55.7558, 37.6173, 59.9343, 30.3351), 5, "Москва - Санкт-Петербург")
+	assert.InDelta(t, 0, Distance(55.75, 37.62, 55.75, 37.62), 1e-9)
+
+	trial := Trial{Sites: []Site{
+		{Name: "Москва", Lat: 55.7558, Lon: 37.6173},
+		{Name: "Без координат"},
+		{Name: "Санкт-Петербург", Lat: 59.9343, Lon: 30.3351},
+		{Name: "Тверь", Lat: 56.8587, Lon: 35.9176},
+	}}
+	assert.True(t, trial.HasSiteLocations())
+	assert.False(t, (&Trial{Sites: []Site{{Name: "Без координат"}}}).HasSiteLocations())
+
+	nearest := trial.NearestSites(59.9, 30.3, 2)
+	if assert.Len(t, nearest, 2) {
+		assert.Equal(t, "Санкт-Петербург", nearest[0].Site.Name)
+		assert.Equal(t, "Тверь", nearest[1].Site.Name)
+		assert.Less(t, nearest[0].Distance, nearest[1].Distance)
+	}
+	assert.Len(t, trial.NearestSites(59.9, 30.3, 10), 3, "Центры без координат пропускаются")
+}
@@ -0,0 +1,239 @@
+package service
+
+// Trials Реестр клинических исследований
+var Trials = []Trial{
+	// Рак молочной железы
+	{
+		Code:    "AREAL",
+		Title:   "Рандомизированное открытое сравнительное клиническое исследование эффективности, безопасности, фармакокинетики и иммуногенности препарата BCD-236 в комбинации с химиотерапией у пациентов с рецидивным и/или метастатическим тройным негативным раком молочной железы",
+		Sponsor: "BIOCAD",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически верифицированный диагноз (имеются документально подтвержденные результаты соответствующих исследований) ТНРМЖ: ER 0-2 балла; PR 0-2 балла; HER2 (≤1+) или HER2 (2+) FISH отрицательный",
+			"ТНРМЖ, прогрессирующий или рецидивирующий на фоне или после проведенной системной терапии",
+			"Субъект получил как минимум 1 линию системной терапии по поводу местнораспространенного нерезектабельного или метастатического ТНРМЖ, или у него развился рецидив заболевания во время или в течение 6 месяцев после завершения послеоперационной (адъювантной) химиотерапии",
+			"Подтвержденная экспрессия AXL в опухолевых клетках по данным\n" +
+				"иммуногистохимического исследования (выполняется бесплатно для пациента)",
+			"Наличие материала для гистологического исследования и/или согласие субъекта на проведение биопсии для определения статуса AXL-экспрессии",
+			"Наличие как минимум 1 измеримого опухолевого очага по критериям RECIST 1.1",
+			"Балл по шкале ECOG 0-1",
+		},
+		Exclusion: []string{
+			"Наличие показаний для проведения радикальной терапии или радиотерапии (исключая малые хирургические операции или лучевую терапию в паллиативных целях)",
+			"Активные метастазы в ЦНС и/или канцероматозный менингит. Субъекты с метастазами в головном мозге могут участвовать в исследовании при условии, что данные метастазы были адекватно пролечены с использованием хирургического вмешательства или радиотерапии",
+		},
+	},
+	{
+		Code:    "BCD-267-1",
+		Title:   "Двойное слепое сравнительное рандомизированное клиническое исследование I фазы по изучению фармакокинетики, безопасности и иммуногенности препарата BCD-267 в монотерапии и препарата сравнения после однократного и многократного внутривенного введения у пациенток с распространенным раком молочной железы",
+		Sponsor: "BIOCAD",
+		Phase:   "I",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически верифицированный рак молочной железы, который:\n" +
+				"a) является местно-распространенным нерезектабельным или метастатическим\n" +
+				"b) имеет HER2-позитивный (люминальный/нелюминальный) молекулярно-биологический подтип\n" +
+				"c) субъект ранее получал и прогрессировал на одной и более линии анти-HER2-терапии по поводу распространенного или метастатического рака молочной железы. Также могут включаться субъекты, у которых зафиксировано прогрессирование в течение 6 месяцев после адъювантного лечения, включавшего анти-HER2-терапию",
+			"Документально подтвержденное рентгенологическое прогрессирование при невозможности применения радикальных методов лечения (во время или после последней линии терапии или в течение 6 месяцев после завершения адъювантной терапии)",
+			"HER2-позитивный (люминальный/нелюминальный) молекулярно-биологический подтип: экспрессия HER2 ИГХ 3+ или ИГХ 2+ с амплификацией по FISH",
+			"Наличие, как минимум, одного измеримого опухолевого очага в соответствии с критериями RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Предшествующая терапия трастузумабом дерукстеканом",
+			"Наличие показаний для проведения радикальной терапии (в т.ч. оперативное лечение, радиотерапия). Доступность иных терапевтических опций, по мнению врача-исследователя более эффективных, чем исследуемая терапия",
+			"Активные метастазы в ЦНС и/или канцероматозный менингит. Субъекты с метастазами в головном мозге могут участвовать в исследовании при условии, что данные метастазы были адекватно пролечены с использованием хирургического вмешательства или радиотерапии",
+			"Активные гепатиты В или С, ВИЧ-инфекция, сифилис",
+		},
+	},
+	{
+		Code:    "CL011101223",
+		Title:   "Международное, многоцентровое, двойное слепое, рандомизированное, сравнительное исследование эффективности, безопасности и фармакокинетики препаратов RPH-051 и Перьета® в комбинации с трастузумабом и доцетакселом в качестве 1-й линии терапии пациентов с HER2-позитивным метастатическим или местнорецидивирующим, неоперабельным раком молочной железы",
+		Sponsor: "Р-Фарм",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически верифицированная метастатическая или местнорецидивирующая, неоперабельная аденокарцинома молочной железы",
+			"Пациенты с метастатическим или местнорецидивирующим, неоперабельным РМЖ, которым показано проведение терапии 1 линии",
+			"HER2-положительный опухолевый статус, определяемый как 3+ балла по результатам иммуногистохимического исследования (ИГХ) и/или выявленная амплификация HER2 по результатам флуоресцентной гибридизации in situ (при уровне HER2 ≥ 2 балла), оцененная с помощью валидированного теста",
+			"Наличие, как минимум, одного измеримого опухолевого очага в соответствии с критериями RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Предшествующая противоопухолевая терапии по поводу метастатического или местнорецидивирующего, неоперабельного РМЖ (неоадъювантная/адъювантная терапия трастузумабом и один режим гормонотерапии по поводу метастатического процесса не рассматриваются в качестве линии терапии)",
+			"Предшествующая терапия пертузумабом",
+			"Период без признаков заболевания от завершения системной неоадъювантной или адъювантной терапии РМЖ (за исключением гормональной терапии) до установления диагноза метастатического процесса < 12 месяцев",
+			"Период от завершения системной неоадъювантной или адъювантной терапии РМЖ трастузумабом и доцетакселом до начала системной терапии по поводу метастатического или местнорецидивирующего, неоперабельного процесса комбинацией пертузумаб+трастузумаб+доцетаксел < 12 месяцев",
+			"Активные метастазы в ЦНС и/или канцероматозный менингит. Субъекты с метастазами в головном мозге могут участвовать в исследовании при условии, что данные метастазы были адекватно пролечены с использованием хирургического вмешательства или радиотерапии",
+			"Наличие в анамнезе лечения кумулятивными дозами антрациклинов",
+			"Активные гепатиты В или С, ВИЧ-инфекция, сифилис",
+		},
+	},
+
+	// Колоректальный рак
+	{
+		Code:    "CL01790199",
+		Title:   "Многоцентровое, двойное слепое, рандомизированное, сравнительное исследование фармакокинетики, безопасности и иммуногенности препарата RPH-030 в сравнении с препаратом Вектибикс® у пациентов с метастатическим колоректальным раком (мКРР) с генами RAS дикого типа в качестве терапии 1 линии в комбинации с FOLFIRI.",
+		Sponsor: "Р-Фарм",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически верифицированная метастатическая колоректальная аденокарцинома",
+			"Согласие пациента на проведение биопсии в рамках скрининга в случае невозможности предоставления архивных образцов для гистологической верификации диагноза",
+			"Пациенты с метастатическим КРР (первично диссеминированным или прогрессирующим в виде появления отдаленных метастазов), которым показано проведение терапии 1 линии",
+			"Гены RAS дикого типа",
+			"Наличие, как минимум, одного измеримого опухолевого очага в соответствии с критериями RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Предшествующая системная противоопухолевая терапия (за исключением неоадъювантной и адъювантной химиотерапии на основе фторпиримидинов, проводимой по меньшей мере в течение 6 месяцев до предполагаемой даты рандомизации в исследование)",
+			"Предшествующая терапия анти-EGFR моноклональными антителами (например, цетуксимаб, панитумумаб) или малыми молекулами – ингибиторами тирозинкиназы EGFR (например, гефитиниб, эрлотиниб, афатиниб)",
+			"Проведение сопутствующей системной иммунотерапии или гормональной терапии рака",
+			"Проведение хирургического лечения менее чем за 28 дней, лучевой терапии (за исключением паллиативной), при которой сохраняются остаточные признаки радиологической токсичности, менее чем за 14 дней до предполагаемой даты рандомизации в исследование",
+			"Наличие мутации гена BRAF (при отсутствии результатов тестирования гена BRAF диагностика будет проводиться в центральной лаборатории)",
+			"Парез кишечника, желудочно-кишечная непроходимость или неконтролируемая диарея (симптомы, приводящие к потере трудоспособности, несмотря на адекватное лечение)",
+			"Метастазы в центральной нервной системе, прогрессирующие или сопровождающиеся клиническими симптомами (например, отек головного мозга, сдавление спинного мозга) или требующие применения глюкокортикостероидов. Пациенты с метастатическим поражением головного мозга могут быть включены в исследование, при условии проведения адекватной терапии (хирургического лечения или радиотерапии) и стабилизации по данным визуализирующих методов исследования на протяжении как минимум 4-х недель до предполагаемой даты рандомизации в исследование. Пациенты с бессимптомными метастазами, не прогрессирующими (по данным КТ/МРТ по сравнению с предыдущим исследованием) и не требующими применения глюкокортикостероидов и/или противосудорожных препаратов на протяжении, как минимум, 4 недель до предполагаемой даты рандомизации в исследование, могут быть включены в исследование. Пациенты с впервые выявленными в рамках скрининга метастазами в ЦНС, которые не сопровождаются неврологической симптоматикой и не требуют терапии, могут быть включены в исследование",
+			"Синдром Жильбера",
+			"Возможность проведения радикального удаления всех метастатических очагов",
+			"Наличие иной онкологической патологии, прогрессирующей или требующей проведения противоопухолевой терапии (в том числе гормональной) в течение 5-ти лет до подписания формы ИС, за исключением радикально удаленной карциномы шейки матки in situ, радикально удаленного РМЖ in situ или радикально удаленной базальноклеточной/плоскоклеточной карциномы кожи",
+			"Обширное хирургическое вмешательство, проведенное менее чем за 28 дней до предполагаемой даты рандомизации в исследование. Под обширным хирургическим вмешательством понимаются процедуры со вскрытием одной из крупных полостей организма (брюшной полости, грудной клетки или черепа), которые проводятся с использованием общей анестезии",
+			"Положительный результат любого из следующих тестов: на поверхностный антиген гепатита B (HBs Ag), на антитела к вирусу гепатита C (anti-HCV), на антитела к вирусу иммунодефицита человека 1 и 2 (anti-HIV1 и anti-HIV2 Ab)",
+		},
+	},
+	{
+		Code:    "GNR-107",
+		Title:   "Двойное слепое рандомизированное исследование фармакокинетики, безопасности и иммуногенности препаратов GNR-107 и Вектибикс® во второй линии лечения в комбинации с FOLFIRI у пациентов с метастатическим колоректальным раком с генами RAS дикого типа, которые получили химиотерапию на основе фторопиримидиновых препаратов, за исключением иринотекана.",
+		Sponsor: "Генериум",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически подтвержденная метастатическая неоперабельная аденокарцинома толстой или прямой кишки с RAS дикого типа",
+			"Один предшествующий режим химиотерапии (первая линия или адъювантная терапия), включающий фторопиримидиновые препараты (за исключением иринотекана)",
+			"Рентгенологически документированное прогрессирование заболевания во время лечения или в течение 6 месяцев после последней дозы химиотерапии первой линии",
+			"Наличие не менее одного измеримого целевого очага по критериям RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Метастатическое поражение центральной нервной системы (ЦНС) в настоящее время или в анамнезе",
+			"Предшествующая терапия антителами против EGFR (например, цетуксимабом) или низкомолекулярными ингибиторами тирозинкиназы EGFR (например, эрлотинибом). Могут участвовать субъекты, которые прекратили прием первой дозы терапии анти-EGFR (Цетуксимаб) из-за инфузионной реакции",
+			"Интерстициальный пневмонит или клинически выраженный (по мнению исследователя) фиброз легких в анамнезе",
+			"Полная или частичная кишечная непроходимость, активное воспалительное заболевание кишечника или другое заболевание кишечника, вызывающее хроническую диарею (определяется как жидкий стул > 4 раз в день)",
+			"Неспецифический язвенный колит, болезнь Крона или перфорация ЖКТ в анамнезе",
+			"Наружные или внутренние свищи, незаживающие раны, костные переломы на момент рандомизации",
+			"Синдром Жильбера в анамнезе",
+			"Положительные результаты теста на инфекцию вируса иммунодефицита человека, вирус гепатита С и гепатита В в анамнезе или на момент включения в исследование",
+		},
+	},
+
+	// Рак легкого, меланома
+	{
+		Code:   "MIT-002",
+		Title:  "Многоцентровое, рандомизированное, двойное слепое сравнительное исследование фармакокинетики, безопасности, иммуногенности и эффективности в параллельных группах препарата MIT-002 и препарата Китруда® у пациентов с распространенными формами злокачественных новообразований различной локализации",
+		Status: StatusRecruiting,
+		Inclusion: []string{
+			"Местнораспространенный неоперабельный или метастатический немелкоклеточный рак легкого (НМРЛ):\n" +
+				"⁃ Стадия IIIB, IIIC или IV согласно TNM\n" +
+				"⁃ Отсутствие активирующих мутаций гена EGFR или трансформационных изменений гена ALK\n" +
+				"⁃ Экспрессия PD-L1 на опухолевых клетках ≥50%\n" +
+				"ИЛИ\n" +
+				"Местнораспространенная неоперабельная или метастатическая меланома кожи:\n" +
+				"⁃ Стадия III или IV согласно TNM",
+			"Наличие показаний к проведению первой линии противоопухолевой терапии в режиме монотерапии пембролизумабом в дозе 200 мг в/в каждые 3 недели (21 день)",
+			"Отсутствие показаний или наличие противопоказаний к хирургическому и/или лучевому лечению",
+			"Наличие по крайней мере одного измеряемого опухолевого очага согласно критериям оценки RECIST 1.1",
+			"Масса тела от 60 до 90 кг на скрининге",
+		},
+		Exclusion: []string{
+			"Диагноз мелкоклеточной карциномы легкого",
+			"Неконтролируемое образование злокачественного плеврального выпота (например, рецидивирующий выпот, который возникает несмотря на дренаж плевральной полости или прием склерозирующих препаратов)",
+			"Радиологические или клинические данные о наличии признаков инвазии опухоли в кровеносные сосуды или о ее расположении вблизи крупных сосудов, которые, по мнению исследователя, могут иметь риск кровотечения",
+			"Наличие в анамнезе системной противоопухолевой терапии по поводу распространенного неоперабельного, рецидивирующего или метастатического онкологического заболевания – НМРЛ или меланомы",
+			"Предшествующая терапия моноклональными антителами и/или молекулярная таргетная терапия",
+			"Лучевая терапия в течение 4 недель до рандомизации (опухолевые очаги, расположенные в ранее облученной области или в области, подвергавшейся воздействию другой локорегионарной терапии, не рассматриваются в качестве измеряемых очагов, за исключением случаев выявления прогрессии в таком очаге)",
+			"Метастазы в центральную нервную систему и/или канцероматозный менингит. Допускается участие в исследовании пациентов с метастазами в головном мозге только при условии, что данные метастазы были адекватно пролечены с использованием только радиотерапии и/или хирургического вмешательства и стабильны по данным визуализирующих методов исследования",
+			"Наличие иного, кроме текущего основного заболевания (меланомы или НМРЛ), злокачественного новообразования в течение последних 5 лет, за исключением злокачественных опухолей, поддающихся лечению посредством локальной терапии, с достигнутой полной ремиссией и отсутствием необходимости в дополнительной терапии, такие как базальный или плоскоклеточный рак кожи, карцинома in situ шейки матки или молочной железы, или поверхностный рак мочевого пузыря",
+			"Увеальная меланома или меланома слизистых оболочек",
+			"Активные, известные или подозреваемые аутоиммунные расстройства (к участию допускаются пациенты с сахарным диабетом 1 типа или гипотиреозом, которым требуется только заместительная гормональная терапия, и пациенты с кожными заболеваниями [витилиго, алопеция или псориаз], не требующими системной терапии)",
+			"Наличие в анамнезе интерстициального заболевания легких или (неинфекционного) пневмонита, требующего терапии глюкокортикоидами, или пневмонит на момент включения в исследование",
+		},
+	},
+
+	// Рак легкого
+	{
+		Code:   "BEV-III/2022",
+		Title:  "Многоцентровое, двойное слепое, рандомизированное, в параллельных группах сравнительное исследование эффективности, безопасности, фармакокинетики и иммуногенности препаратов Бевацизумаб и Авастин® в комбинации с паклитакселом и карбоплатином у взрослых пациентов с распространенным неоперабельным, метастатическим или рецидивирующим неплоскоклеточным немелкоклеточным раком легкого",
+		Status: StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически подтвержденный распространенный неоперабельный, метастатический или рецидивирующий неплоскоклеточный НМРЛ. Стадии TNM: распространенный неоперабельный – IIIB (T1a–c, Т2a, Т2b /N3 /M0 или T3, T4 /N2/M0) или IIIC, метастатический – IV, рецидивирующий – любая стадия до радикального лечения",
+			"Отсутствие показаний или наличие противопоказаний к хирургическому и/или лучевому лечению",
+			"Наличие по крайней мере одного измеряемого опухолевого очага согласно критериям оценки RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Диагноз мелкоклеточной карциномы легкого или плоскоклеточной карциномы легкого",
+			"Диагностированные активирующие мутации гена EGFR или трансформационные изменения гена ALK",
+			"Радиологические или клинические данные о наличии признаков инвазии опухоли в кровеносные сосуды или о ее расположении вблизи крупных сосудов, которые, по мнению Исследователя, могут иметь риск кровотечения",
+			"Наличие в анамнезе системной противоопухолевой терапии распространенного неоперабельного, метастатического или рецидивирующего НМРЛ",
+			"Неоадъювантная или адъювантная химиотерапия НМРЛ, завершенная менее чем за 12 месяцев до рандомизации",
+			"Предшествующая терапия моноклональными антителами и/или молекулярная таргетная терапия, предыдущее введение ингибитора сосудистого эндотелиального фактора роста (VEGF)",
+			"Лучевая терапия в течение 14 дней до рандомизации (опухолевые очаги, расположенные в ранее облученной области или в области, подвергавшейся воздействию другой локорегионарной терапии, не рассматриваются в качестве таргетных очагов)",
+			"Неконтролируемое образование плеврального выпота (например, рецидивирующий выпот, который возникает несмотря на дренаж плевральной полости или прием склерозирующих препаратов)",
+			"Метастазы в центральную нервную систему и/или канцероматозный менингит",
+			"Любое из следующих событий в течение 12 месяцев до начала скрининга:\n" +
+				"⁃ Инфаркт миокарда или нестабильная стенокардия\n" +
+				"⁃ Клинически значимое/неконтролируемое нарушение сердечного ритма\n" +
+				"⁃ Тромбоэмболия легочной артерии\n" +
+				"⁃ Застойная сердечная недостаточность (ЗСН) в анамнезе (функциональный класс II или выше согласно классификации Нью-Йоркской кардиологической ассоциации [NYHA])\n" +
+				"⁃ Шунтирование коронарных/периферических артерий\n" +
+				"⁃ Инсульт или транзиторная ишемическая атака\n" +
+				"⁃ Синдром задней обратимой энцефалопатии\n" +
+				"⁃ Тромбоз глубоких вен\n" +
+				"⁃ Свищ брюшной полости, а также свищ другой локализации (кроме отделов ЖКТ), желудочно-кишечная перфорация и/или свищ, желудочно-кишечно-влагалищный свищ или внутрибрюшной абсцесс\n" +
+				"⁃ Желудочно-кишечные кровотечения и/или кровохарканье или кровавая рвота (≥ 1/2 чайной ложки крови), или любые другие случаи массивных кровотечений",
+			"Активные гепатиты В или С, ВИЧ-инфекция, сифилис",
+		},
+	},
+
+	// Рак головы и шеи
+	{
+		Code:    "RPH-002",
+		Title:   "Международное многоцентровое открытое, рандомизированное, сравнительное исследование эффективности и безопасности препаратов RPH-002 и Эрбитукс® у пациентов с неоперабельным метастатическим или рецидивирующим плоскоклеточным раком головы и шеи",
+		Sponsor: "Р-Фарм",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Наличие гистологически подтвержденного диагноза плоскоклеточного рака головы и шеи",
+			"Наличие документированного неоперабельного локорегионарного рецидива ПРГШ или рецидива с отдаленными метастазами (или в виде только отдаленных метастазов) или прогрессирования заболевания после первичного химиолучевого или комбинированного лечения, завершенного за более чем 3 месяца до визита скрининга, в отношении которого неприменимы методы местного лечения (за исключением случаев, по оценке исследователя, сопряженных с высоким риском развития синдрома лизиса опухоли или кровотечений). В исследование могут быть включены пациенты со следующими ответами на предшествующую терапию: полный ответ, частичный ответ, стабилизация заболевания.\n" +
+				"ИЛИ\n" +
+				"Наличие впервые документированного распространенного ПРГШ с отдаленными метастазами, в отношении которого противоопухолевого лечения ранее не проводилось и не применимы методы местного лечения",
+			"Наличие по крайней мере одного измеряемого опухолевого очага согласно критериям оценки RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Предшествующая терапия основного заболевания препаратом цетуксимаб или другими биологическими препаратами, содержащими моноклональные антитела",
+			"Химиотерапия, радиотерапия, а также хирургическое лечение ПРГШ, проведенные менее чем за 3 месяца до проведения визита скрининга",
+			"Любое обширное хирургическое вмешательство (за исключением предшествующей биопсии; установки имплантируемой венозной порт-системы; хирургического вмешательства по витальным показаниям, не связанным с основным заболеванием) менее чем за 3 месяцев до проведения визита скрининга",
+			"Рак носоглотки",
+			"Наличие подтвержденных метастазов в головной мозг или мягкую и паутинную оболочки или подозрение на наличие метастазов, сопровождающееся симптоматикой",
+			"Активные гепатиты В или С, ВИЧ-инфекция, сифилис",
+		},
+	},
+
+	// Рак желудка
+	{
+		Code:    "RB-012",
+		Title:   "Многоцентровое, международное, двойное слепое, рандомизированное, сравнительное исследование эффективности и безопасности препаратов RB-012 и Цирамза® в комбинации с паклитакселом у больных местно-распространенным, рецидивирующим или метастатическим раком желудка или пищеводно-желудочного перехода при прогрессировании на фоне или после терапии 1 линии",
+		Sponsor: "Р-Фарм",
+		Status:  StatusRecruiting,
+		Inclusion: []string{
+			"Гистологически верифицированная аденокарцинома желудка или пищеводно-желудочного перехода",
+			"Пациенты с местно-распространенным, метастатическим или рецидивирующим раком желудка или пищеводно-желудочного перехода с прогрессированием на фоне или после терапии 1 линии",
+			"Прогрессирование заболевания на фоне или в течение не более 3-х месяцев после завершения терапии 1-й линии",
+			"Наличие по крайней мере одного измеряемого опухолевого очага согласно критериям оценки RECIST 1.1",
+		},
+		Exclusion: []string{
+			"Плоскоклеточный или недифференцированный рак желудка",
+			"Наличие в анамнезе более 1-й линии терапии по поводу местно-распространенного, метастатического или рецидивирующего рака желудка или пищеводно-желудочного перехода (проведение неоадъювантной/адъювантной терапии по поводу более ранних стадий заболевания не учитывается в качестве отдельной линии терапии)",
+			"Предшествующая химиотерапия паклитакселом",
+			"Предшествующая системная терапия другими антиангиогенными препаратами",
+			"Метастазы в центральной нервной системе, прогрессирующие или сопровождающиеся клиническими симптомами. Пациенты с метастатическим поражением головного мозга могут быть включены в исследование, при условии проведения адекватной терапии (хирургического лечения или радиотерапии) и стабилизации по данным визуализирующих методов исследования на протяжении как минимум 4-х недель до предполагаемой даты рандомизации в исследование",
+			"Перфорация или свищи желудочно-кишечного тракта, зарегистрированные в течение 6 месяцев до предполагаемой даты рандомизации в исследование",
+			"Кишечная непроходимость, наличие воспалительной энтеропатии или обширной резекции кишки (гемиколэктомия или расширенная резекция тонкой кишки, повлекшая хроническую диарею) в анамнезе, болезнь Крона, язвенный колит или хроническая диарея",
+			"Значительное кровотечение, в том числе желудочно-кишечное, васкулит, зарегистрированные в течение 3 месяцев до предполагаемой даты рандомизации в исследование",
+			"Любая артериальная тромбоэмболия, зарегистрированная в течение 6 месяцев до предполагаемой даты рандомизации в исследование",
+			"Значительная венозная тромбоэмболия, зарегистрированная в течение 3 месяцев до предполагаемой даты рандомизации в исследование",
+			"Наличие незаживающих ран, язв на момент скринингового обследования",
+			"Активные гепатиты В или С, ВИЧ-инфекция, сифилис",
+		},
+	},
+}